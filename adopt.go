@@ -0,0 +1,75 @@
+package ex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Adopt converts a chain built with fmt.Errorf("...: %w", err) into the equivalent ex chain, so mixed-style
+// chains render, traverse and match consistently: every wrapping level becomes a link showing its own prefix
+// and caused by the wrapped error, e.g. fmt.Errorf("load: %w", io.EOF) becomes a chain "load" → io.EOF.
+// Flatten, ExposeAll, CauseString and the like then descend through the levels instead of stopping
+// at the outermost standard error.
+//
+// The adopted links still match the original wrappers (and anything they wrap) with errors.Is and errors.As.
+// The adoption stops at the first level that is an ex chain, wraps more than one error, or doesn't end with
+// ": " and the message of the wrapped error; that level becomes the cause as is. If nothing can be adopted,
+// the result is the same as Conv. If the error is nil, the result will also be nil.
+func Adopt(err error) XError {
+	if err == nil {
+		return nil
+	}
+
+	levels, tail := walk(err)
+	if len(levels) == 0 {
+		return Conv(err)
+	}
+
+	chain, _ := fold(levels, tail).(XError)
+
+	return chain
+}
+
+// wrapErrorType is the type of the errors created by fmt.Errorf with a single %w verb.
+//
+//nolint:gochecknoglobals // read-only
+var wrapErrorType = reflect.TypeOf(fmt.Errorf("%w", ErrUnknown))
+
+// adoptLevel adopts the fmt.Errorf wrapping level as a link showing its own prefix and caused by the wrapped error.
+// Only the wrappers ending with ": " and the message of the wrapped error are adopted.
+func adoptLevel(err error) (*xError, bool) {
+	if reflect.TypeOf(err) != wrapErrorType {
+		return nil, false
+	}
+
+	inner := errors.Unwrap(err)
+	if inner == nil {
+		return nil, false
+	}
+
+	text, ok := strings.CutSuffix(err.Error(), Separator+inner.Error())
+	if !ok {
+		return nil, false
+	}
+
+	return &xError{error: &adopted{wrapper: err, text: text}, cause: inner, meta: nil, text: "", flags: 0}, true
+}
+
+// adopted is the identity of a level adopted from a fmt.Errorf chain: it shows the prefix of the level
+// and unwraps to the original wrapper, so it still matches everything the wrapper does.
+type adopted struct {
+	wrapper error  // The original wrapping level.
+	text    string // The prefix of the level.
+}
+
+// Error returns the prefix of the level.
+func (a *adopted) Error() string {
+	return a.text
+}
+
+// Unwrap returns the original wrapping level, allowing compatibility with errors.Is and errors.As.
+func (a *adopted) Unwrap() error {
+	return a.wrapper
+}
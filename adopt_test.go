@@ -0,0 +1,112 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestAdopt(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	type args struct {
+		err error
+	}
+
+	type want struct {
+		flatten []string
+		cause   string
+		message string
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want want
+	}{
+		{
+			name: "fmt chain",
+			args: args{err: fmt.Errorf("load: %w", fmt.Errorf("read: %w", io.EOF))},
+			want: want{flatten: []string{"load", "read", "EOF"}, cause: "EOF", message: "load: read: EOF"},
+		},
+		{
+			name: "ex inside fmt",
+			args: args{err: fmt.Errorf("svc: %w", userErr.Because(dbErr.Reason("timeout")))},
+			want: want{
+				flatten: []string{"svc", "user not found", "database error", "timeout"},
+				cause:   "timeout",
+				message: "svc: user not found: database error: timeout",
+			},
+		},
+		{
+			name: "fmt inside ex",
+			args: args{err: userErr.Because(fmt.Errorf("query: %w", dbErr.Because(io.EOF)))},
+			want: want{
+				flatten: []string{"user not found", "query", "database error", "EOF"},
+				cause:   "EOF",
+				message: "user not found: query: database error: EOF",
+			},
+		},
+		{
+			name: "suffix wrapping",
+			args: args{err: fmt.Errorf("load: %w", fmt.Errorf("%w: at line 3", io.ErrUnexpectedEOF))},
+			want: want{
+				flatten: []string{"load", "unexpected EOF: at line 3"},
+				cause:   "unexpected EOF: at line 3",
+				message: "load: unexpected EOF: at line 3",
+			},
+		},
+		{
+			name: "nothing to adopt",
+			args: args{err: errors.New("standard")},
+			want: want{flatten: []string{"standard"}, cause: "", message: "standard"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.Adopt(test.args.err)
+
+			require.EqualError(t, got, test.want.message)
+			require.Equal(t, test.want.flatten, ex.Flatten(got))
+			require.Equal(t, test.want.cause, ex.CauseString(got))
+		})
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			pathErr = &fs.PathError{Op: "open", Path: "/etc/app.conf", Err: fs.ErrNotExist}
+			wrapped = fmt.Errorf("config: %w", pathErr)
+			got     = ex.Adopt(fmt.Errorf("start: %w", wrapped))
+			target  *fs.PathError
+		)
+
+		require.ErrorIs(t, got, wrapped)
+		require.ErrorIs(t, got, fs.ErrNotExist)
+		require.ErrorAs(t, got, &target)
+		require.Same(t, pathErr, target)
+		require.ErrorIs(t, ex.Error("boot").Because(got), fs.ErrNotExist)
+		require.EqualError(t, got.Reason("timeout"), "start: timeout")
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, ex.Adopt(nil))
+	})
+}
@@ -0,0 +1,105 @@
+package ex
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// aliases holds the equivalences registered with Alias, as an undirected graph of identities.
+//
+//nolint:gochecknoglobals // the aliases are process-wide by design
+var aliases struct {
+	links map[Error]map[Error]int
+	count atomic.Int64
+	mu    sync.RWMutex
+}
+
+// Alias registers the old and renamed identities as equivalent, e.g. during a rename of "db error"
+// to "storage error": errors.Is matches chains built with either identity against the other.
+// The equivalence is bidirectional and transitive, so aliasing A to B and B to C makes A match C.
+// Cycles are harmless. The returned function removes the alias, e.g. in test cleanup.
+func Alias(old, renamed Error) func() {
+	if old == renamed {
+		return func() {}
+	}
+
+	aliases.mu.Lock()
+	defer aliases.mu.Unlock()
+
+	if aliases.links == nil {
+		aliases.links = make(map[Error]map[Error]int)
+	}
+
+	link(old, renamed, 1)
+	link(renamed, old, 1)
+	aliases.count.Add(1)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			aliases.mu.Lock()
+			defer aliases.mu.Unlock()
+
+			link(old, renamed, -1)
+			link(renamed, old, -1)
+			aliases.count.Add(-1)
+		})
+	}
+}
+
+// Is reports whether the target is an identity aliased to the Error (see Alias).
+// The errors.Is function has already compared the identities themselves.
+func (c Error) Is(target error) bool {
+	other, ok := target.(Error)
+	if !ok || aliases.count.Load() == 0 {
+		return false
+	}
+
+	return aliased(c, other)
+}
+
+// link adds the delta to the number of aliases from one identity to the other.
+// The caller must hold the lock.
+func link(from, to Error, delta int) {
+	if aliases.links[from] == nil {
+		aliases.links[from] = make(map[Error]int)
+	}
+
+	aliases.links[from][to] += delta
+
+	if aliases.links[from][to] <= 0 {
+		delete(aliases.links[from], to)
+	}
+
+	if len(aliases.links[from]) == 0 {
+		delete(aliases.links, from)
+	}
+}
+
+// aliased reports whether the identities are connected through the registered aliases.
+func aliased(from, to Error) bool {
+	aliases.mu.RLock()
+	defer aliases.mu.RUnlock()
+
+	seen := map[Error]bool{from: true}
+	queue := []Error{from}
+
+	for len(queue) > 0 {
+		identity := queue[0]
+		queue = queue[1:]
+
+		for next := range aliases.links[identity] {
+			if next == to {
+				return true
+			}
+
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return false
+}
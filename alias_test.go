@@ -0,0 +1,81 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestAlias(t *testing.T) {
+	t.Parallel()
+
+	const (
+		dbErr      = ex.Error("alias test: db error")
+		storageErr = ex.Error("alias test: storage error")
+		diskErr    = ex.Error("alias test: disk error")
+		userErr    = ex.Error("alias test: user not found")
+	)
+
+	removeStorage := ex.Alias(dbErr, storageErr)
+	removeDisk := ex.Alias(storageErr, diskErr)
+
+	type args struct {
+		err    error
+		target error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{name: "old to new", args: args{err: dbErr, target: storageErr}, want: true},
+		{name: "new to old", args: args{err: storageErr, target: dbErr}, want: true},
+		{name: "chained aliases", args: args{err: dbErr, target: diskErr}, want: true},
+		{name: "chained aliases back", args: args{err: diskErr, target: dbErr}, want: true},
+		{name: "wrapped", args: args{err: userErr.Because(dbErr.Reason("timeout")), target: storageErr}, want: true},
+		{name: "not aliased", args: args{err: userErr, target: dbErr}, want: false},
+		{name: "not aliased back", args: args{err: storageErr, target: userErr}, want: false},
+		{name: "standard error", args: args{err: errors.New(string(dbErr)), target: storageErr}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, errors.Is(test.args.err, test.args.target))
+		})
+	}
+
+	t.Run("removed", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			oldErr = ex.Error("alias test: old")
+			newErr = ex.Error("alias test: new")
+		)
+
+		remove := ex.Alias(oldErr, newErr)
+		again := ex.Alias(newErr, oldErr)
+
+		remove()
+		remove()
+
+		require.ErrorIs(t, oldErr, newErr)
+
+		again()
+
+		require.NotErrorIs(t, oldErr, newErr)
+		require.NotErrorIs(t, newErr, oldErr)
+	})
+
+	t.Cleanup(func() {
+		removeStorage()
+		removeDisk()
+
+		require.NotErrorIs(t, dbErr, storageErr)
+	})
+}
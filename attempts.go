@@ -0,0 +1,26 @@
+package ex
+
+// attemptsKey is the metadata key of the attempt count.
+type attemptsKey struct{}
+
+// WithAttempts creates a new xError, using the current Error as the root and attaching
+// the number of attempts made before the operation failed, e.g. "failed after 5 attempts".
+func (c Error) WithAttempts(n int) error {
+	return attach(c, attemptsKey{}, n)
+}
+
+// WithAttempts attaches the number of attempts made before the operation failed
+// to the outermost link of the error, e.g. after retries are exhausted.
+func WithAttempts(err error, n int) error {
+	return attach(err, attemptsKey{}, n)
+}
+
+// Attempts returns the attempt count of the nearest link of the error chain that has one.
+func Attempts(err error) (int, bool) {
+	return lookup[int](err, attemptsKey{})
+}
+
+// AttemptsOf is the same as Attempts, named like the other accessors (SeverityOf, DurationOf and so on).
+func AttemptsOf(err error) (int, bool) {
+	return Attempts(err)
+}
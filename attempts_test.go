@@ -0,0 +1,89 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestAttempts(t *testing.T) {
+	t.Parallel()
+
+	const (
+		syncErr  = ex.Error("sync failed")
+		fetchErr = ex.Error("fetch failed")
+	)
+
+	t.Run("survives wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = ex.WithAttempts(fetchErr.Because(errors.New("timeout")), 3)
+			err   = syncErr.Because(inner)
+		)
+
+		attempts, ok := ex.Attempts(err)
+
+		require.True(t, ok)
+		require.Equal(t, 3, attempts)
+		require.EqualError(t, err, "sync failed: fetch failed: timeout")
+		require.Equal(t, "sync failed\nfetch failed\n    attempts: 3\ntimeout", ex.Dump(err))
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			err     = syncErr.WithAttempts(5)
+			outer   = fetchErr.Because(ex.WithAttempts(err, 2))
+			fetched = ex.WithAttempts(outer, 7)
+		)
+
+		attempts, ok := ex.AttemptsOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, 5, attempts)
+		require.ErrorIs(t, err, syncErr)
+		require.EqualError(t, err, "sync failed")
+
+		attempts, ok = ex.AttemptsOf(outer)
+
+		require.True(t, ok)
+		require.Equal(t, 2, attempts)
+
+		attempts, ok = ex.AttemptsOf(fetched)
+
+		require.True(t, ok)
+		require.Equal(t, 7, attempts)
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			causeErr = errors.New("timeout")
+			err      = ex.WithAttempts(causeErr, 5)
+		)
+
+		attempts, ok := ex.Attempts(err)
+
+		require.True(t, ok)
+		require.Equal(t, 5, attempts)
+		require.ErrorIs(t, err, causeErr)
+		require.NoError(t, ex.WithAttempts(nil, 5))
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), syncErr.Reason("timeout")} {
+			attempts, ok := ex.Attempts(err)
+
+			require.False(t, ok)
+			require.Zero(t, attempts)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package ex
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// Attr is a single structured attribute of an error chain, see Attrs.
+type Attr struct {
+	Value any    // The value: a string, an int, a time.Duration, a []string, a []Attr or a field value.
+	Key   string // The name of the attribute.
+}
+
+// Attrs describes the ex chain as the attributes for structured loggers, so every adapter
+// only maps them to its own attribute types. The attributes are, in order (when present):
+//   - "identity" (string): the message of the outermost identity;
+//   - "cause" (string): the message of the root cause (see CauseString);
+//   - "chain" ([]string): the messages of the links (see Flatten);
+//   - "code" (int), "owner" (string) and "duration" (time.Duration): see CodeOf, OwnerOf and DurationOf;
+//   - "fields" ([]Attr): the fields (see Fields) sorted by name;
+//   - "stack" ([]string): the frames of FirstStack as "function file:line" lines.
+//
+// If the error is nil or a standard error (its outermost link is not an ex link), the result will be nil.
+func Attrs(err error) []Attr {
+	links := ExposeAll(err)
+	if len(links) == 0 || links[0].Foreign {
+		return nil
+	}
+
+	attrs := []Attr{{Key: "identity", Value: links[0].Identity.Error()}}
+
+	if cause := CauseString(err); cause != "" {
+		attrs = append(attrs, Attr{Key: "cause", Value: cause})
+	}
+
+	attrs = append(attrs, Attr{Key: "chain", Value: Flatten(err)})
+
+	if code, ok := CodeOf(err); ok {
+		attrs = append(attrs, Attr{Key: "code", Value: code})
+	}
+
+	if owner, ok := OwnerOf(err); ok {
+		attrs = append(attrs, Attr{Key: "owner", Value: owner})
+	}
+
+	if duration, ok := DurationOf(err); ok {
+		attrs = append(attrs, Attr{Key: "duration", Value: duration})
+	}
+
+	if fields := Fields(err); fields != nil {
+		group := make([]Attr, 0, len(fields))
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			group = append(group, Attr{Key: name, Value: fields[name]})
+		}
+
+		attrs = append(attrs, Attr{Key: "fields", Value: group})
+	}
+
+	if HasStack(err) {
+		stack := FirstStack(err)
+
+		lines := make([]string, 0, len(stack))
+		for _, frame := range stack {
+			lines = append(lines, frame.Function+" "+frame.File+":"+strconv.Itoa(frame.Line))
+		}
+
+		attrs = append(attrs, Attr{Key: "stack", Value: lines})
+	}
+
+	return attrs
+}
@@ -0,0 +1,66 @@
+package ex_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestAttrs(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("attrs test: user not found")
+		dbErr   = ex.Error("attrs test: database error")
+	)
+
+	ex.RegisterCode(userErr, 404)
+	ex.RegisterOwner(dbErr, "storage-team")
+
+	t.Run("ex chain", func(t *testing.T) {
+		t.Parallel()
+
+		err := userErr.Because(dbErr.Because(errors.New("connection reset")))
+		err = ex.WithDuration(ex.WithField(ex.WithField(err, "user", 42), "tenant", "acme"), time.Second)
+
+		require.Equal(t, []ex.Attr{
+			{Key: "identity", Value: "attrs test: user not found"},
+			{Key: "cause", Value: "connection reset"},
+			{
+				Key:   "chain",
+				Value: []string{"attrs test: user not found", "attrs test: database error", "connection reset"},
+			},
+			{Key: "code", Value: 404},
+			{Key: "owner", Value: "storage-team"},
+			{Key: "duration", Value: time.Second},
+			{Key: "fields", Value: []ex.Attr{{Key: "tenant", Value: "acme"}, {Key: "user", Value: 42}}},
+		}, ex.Attrs(err))
+	})
+
+	t.Run("stack", func(t *testing.T) {
+		t.Parallel()
+
+		attrs := ex.Attrs(ex.WithStack(dbErr))
+		last := attrs[len(attrs)-1]
+
+		require.Equal(t, "stack", last.Key)
+
+		lines, ok := last.Value.([]string)
+
+		require.True(t, ok)
+		require.NotEmpty(t, lines)
+		require.True(t, strings.HasPrefix(lines[0], "github.com/therenotomorrow/ex_test.TestAttrs"))
+	})
+
+	t.Run("not a chain", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, ex.Attrs(nil))
+		require.Nil(t, ex.Attrs(errors.New("standard")))
+	})
+}
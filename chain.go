@@ -0,0 +1,510 @@
+package ex
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Link describes a single level of the error chain.
+type Link struct {
+	Identity error // The identity of the level.
+	Cause    error // The cause of the level (can be nil).
+	Foreign  bool  // Whether the level is a standard error terminating the chain.
+}
+
+// ExposeAll unwraps the whole error chain into its levels, from the outermost down.
+// Unlike Expose it reveals every identity/cause pair, so encoders and filters can work with
+// the structure of the chain rather than its message. A standard error terminating the chain
+// appears as the last link marked as Foreign.
+// The chain is transformed with the hook set with SetRenderHook first.
+func ExposeAll(err error) []Link {
+	levels, tail := unfold(hooked(err))
+
+	links := make([]Link, 0, len(levels)+1)
+	for _, level := range levels {
+		links = append(links, Link{Identity: level.error, Cause: level.because(), Foreign: false})
+	}
+
+	if tail != nil {
+		links = append(links, Link{Identity: tail, Cause: nil, Foreign: true})
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	return links
+}
+
+// Flatten returns the messages of the chain links in order, from the outermost down.
+// For ex chains the messages joined with Separator are what the Error method returns, so empty messages
+// are skipped. The only difference is the block of Error.ReasonBlock: it is the last message, kept with
+// its newlines, and the Error method starts it on its own line. If the error is nil or has no messages,
+// the result will also be nil.
+func Flatten(err error) []string {
+	list, _ := messages(err, render)
+
+	return list
+}
+
+// CauseString returns the message of the root cause of the error chain, that is its innermost link.
+// For a standard error it returns the error message, and for an error without a cause or nil it returns "".
+// The chain is transformed with the hook set with SetRenderHook first.
+func CauseString(err error) string {
+	levels, tail := unfold(hooked(err))
+
+	switch {
+	case tail != nil:
+		return tail.Error()
+	case len(levels) > 1:
+		return levels[len(levels)-1].error.Error()
+	default:
+		return ""
+	}
+}
+
+// DistinctRootCauses tallies the errors by the message of their root cause (see CauseString),
+// surfacing the dominant underlying failure, e.g. for incident analysis.
+// An error without a cause is its own root, so it is tallied by its message. Nil errors are skipped.
+func DistinctRootCauses(errs []error) map[string]int {
+	tally := make(map[string]int)
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		root := CauseString(err)
+		if root == "" {
+			root = err.Error()
+		}
+
+		tally[root]++
+	}
+
+	return tally
+}
+
+// TopN renders only the outermost n links of the error chain like the Error method does,
+// appending "…" as the last link if the chain is longer. This bounds the message by the link count
+// rather than by characters, e.g. for compact logs.
+// If the error is nil or n is not positive, the result will be empty.
+func TopN(err error, n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	list, isBlock := messages(err, render)
+	if len(list) > n {
+		list, isBlock = append(list[:n], "…"), false
+	}
+
+	return join(list, isBlock)
+}
+
+// StringDedup renders the error chain like the Error method does, but collapses consecutive identical links
+// into one with a "(xN)" count, e.g. "timeout (x3): connection reset" instead of
+// "timeout: timeout: timeout: connection reset". Distinct links are kept as is, the chain itself is untouched.
+// If the error is nil, the result will be empty.
+func StringDedup(err error) string {
+	list, isBlock := messages(err, render)
+
+	// the block never collapses into the link above it, so it stays on its own line
+	plain := len(list)
+	if isBlock {
+		plain--
+	}
+
+	deduped := make([]string, 0, len(list))
+
+	for i := 0; i < plain; {
+		count := 1
+		for i+count < plain && list[i+count] == list[i] {
+			count++
+		}
+
+		if count > 1 {
+			deduped = append(deduped, list[i]+" (x"+strconv.Itoa(count)+")")
+		} else {
+			deduped = append(deduped, list[i])
+		}
+
+		i += count
+	}
+
+	return join(append(deduped, list[plain:]...), isBlock)
+}
+
+// DedupePrefix renders the error chain like the Error method does, but collapses the repeated leading
+// tokens into one, e.g. "svc: db error" instead of "svc: svc: svc: db error" when every layer prepends
+// the service name. Tokens are split by the Separator, so the prefixes added with fmt.Errorf collapse too.
+// Only the leading run is collapsed, repeated tokens further down are kept as is, see StringDedup for them.
+// This is a rendering helper only, the chain itself is untouched. If the error is nil, the result will be empty.
+func DedupePrefix(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	tokens := strings.Split(err.Error(), Separator)
+
+	skip := 0
+	for skip+1 < len(tokens) && tokens[skip+1] == tokens[0] {
+		skip++
+	}
+
+	return strings.Join(tokens[skip:], Separator)
+}
+
+// TopIdentities returns up to n outermost distinct identity messages of the error chain in order,
+// e.g. as a bounded set of metric labels. The standard error terminating the chain is not an identity.
+// If the error is nil or n is not positive, the result will be nil.
+func TopIdentities(err error, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	levels, _ := unfold(err)
+
+	var labels []string
+
+	for _, level := range levels {
+		label := level.error.Error()
+		if slices.Contains(labels, label) {
+			continue
+		}
+
+		if labels = append(labels, label); len(labels) == n {
+			break
+		}
+	}
+
+	return labels
+}
+
+// LabelKey returns the outermost identity of the error chain as a single token safe for a metric label value,
+// e.g. "user not found" becomes "user_not_found". Interpolated identities (see Error.With) use their sentinel,
+// so the set of keys stays bounded. The identity is slugified by the rules:
+//   - letters are lowercased, ASCII letters and digits are kept;
+//   - every run of other characters (spaces, punctuation, non-ASCII) becomes a single "_";
+//   - leading and trailing "_" are trimmed.
+//
+// If the error is nil, a standard error, or its identity has no letters or digits, the result will be "unknown".
+func LabelKey(err error) string {
+	sentinel, ok := sentinelOf(err)
+	if !ok {
+		return "unknown"
+	}
+
+	var builder strings.Builder
+
+	gap := false
+
+	for _, char := range strings.ToLower(string(sentinel)) {
+		if ('a' <= char && char <= 'z') || ('0' <= char && char <= '9') {
+			if gap && builder.Len() > 0 {
+				builder.WriteByte('_')
+			}
+
+			builder.WriteRune(char)
+
+			gap = false
+		} else {
+			gap = true
+		}
+	}
+
+	if builder.Len() == 0 {
+		return "unknown"
+	}
+
+	return builder.String()
+}
+
+// SharesIdentity checks if the two error chains have at least one identity in common.
+// Only identity links take part in the check, standard errors terminating the chains are ignored,
+// so a chain of a standard error only never shares anything.
+func SharesIdentity(a, b error) bool {
+	ones, _ := unfold(a)
+	others, _ := unfold(b)
+
+	for _, one := range ones {
+		for _, other := range others {
+			if errors.Is(one.error, other.error) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// EqualXError checks if the two errors are structurally equal: the same identity messages in the same order
+// and the same cause text terminating the chains. Unlike comparing the values (e.g. with require.Equal),
+// it ignores how the chains were built, their metadata and the types of the causes,
+// so two chains built independently from the same sentinels and causes are equal.
+// Links with an empty (nil) identity are skipped. Two nil errors are equal.
+func EqualXError(a, b XError) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	ones, oneTail := unfold(a)
+	others, otherTail := unfold(b)
+
+	if len(ones) != len(others) || (oneTail == nil) != (otherTail == nil) {
+		return false
+	}
+
+	for i := range ones {
+		if ones[i].error.Error() != others[i].error.Error() {
+			return false
+		}
+	}
+
+	return oneTail == nil || oneTail.Error() == otherTail.Error()
+}
+
+// Match checks if the errors match each other in either direction when at least one of them is an ex error.
+// It reports true if errors.Is(err, target) does. Otherwise, if err or target is an ex error (an Error
+// or a chain), it also reports true if errors.Is(target, err) does, so matching is order-insensitive
+// for ex types: Match(stdErr, chain) finds stdErr among the identities and causes of the chain.
+//
+// It differs from plain errors.Is only when the target is an ex chain containing err:
+// errors.Is(stdErr, chain) is false, Match(stdErr, chain) is true. Two standard errors are compared
+// by errors.Is alone, in the natural direction.
+func Match(err, target error) bool {
+	if errors.Is(err, target) {
+		return true
+	}
+
+	if !isEx(err) && !isEx(target) {
+		return false
+	}
+
+	return errors.Is(target, err)
+}
+
+// isEx checks if the error is an Error or an ex chain.
+func isEx(err error) bool {
+	if _, ok := err.(Error); ok {
+		return true
+	}
+
+	_, ok := asXError(err)
+
+	return ok
+}
+
+// DepthOf returns the zero-based depth of the outermost link of the error chain matching the target,
+// e.g. to tell how deeply a known error is buried. Links are matched with errors.Is, the same way the Is method
+// of the chain walks them, so DepthOf finds the target exactly when errors.Is does.
+func DepthOf(err, target error) (int, bool) {
+	if target == nil {
+		return 0, false
+	}
+
+	levels, tail := unfold(err)
+
+	for depth, level := range levels {
+		if errors.Is(level.error, target) {
+			return depth, true
+		}
+	}
+
+	if tail != nil && errors.Is(tail, target) {
+		return len(levels), true
+	}
+
+	return 0, false
+}
+
+// Trim caps the error chain at the given depth, keeping only the outermost links.
+// The truncation is recorded as a final synthetic cause, e.g. "…(4 more)",
+// so the removed links are no longer matchable with errors.Is.
+// If the depth is not positive, the result error will be nil.
+// If the chain is not deeper than the depth, an equal copy of the chain is returned.
+func Trim(err error, depth int) error {
+	if err == nil || depth <= 0 {
+		return nil
+	}
+
+	levels, tail := unfold(err)
+
+	size := len(levels)
+	if tail != nil {
+		size++
+	}
+
+	if depth >= size {
+		return fold(levels, tail)
+	}
+
+	return fold(levels[:depth], Error("…("+strconv.Itoa(size-depth)+" more)"))
+}
+
+// SplitAt divides the error chain at the outermost link matching the identity with errors.Is:
+// the outer part keeps the links from the top down to (and including) the matching one,
+// the inner part keeps the rest below it, e.g. to handle the public and internal portions separately.
+// Both parts are copies, the metadata of the links is preserved.
+// If no link matches, the whole chain is the outer part and the inner part is nil.
+func SplitAt(err error, at Error) (error, error) {
+	levels, tail := unfold(err)
+
+	for depth, level := range levels {
+		if errors.Is(level.error, at) {
+			return fold(levels[:depth+1], nil), fold(levels[depth+1:], tail)
+		}
+	}
+
+	return err, nil
+}
+
+// Normalize returns a copy of the error chain where every link is ex-native: the standard error terminating
+// the chain becomes a link of its own, like Conv does, so traversal code can assume ex links throughout.
+// The Error message and errors.Is matching are unchanged, metadata of the links is preserved.
+// If the error is nil, the result will also be nil.
+func Normalize(err error) XError {
+	levels, tail := unfold(err)
+	if tail != nil {
+		levels = append(levels, &xError{error: tail, cause: nil, meta: nil, text: "", flags: 0})
+	}
+
+	if len(levels) == 0 {
+		return nil
+	}
+
+	xer, _ := fold(levels, nil).(*xError)
+
+	return xer
+}
+
+// sentinelOf returns the sentinel of the outermost link of the error chain, a bare Error is its own sentinel.
+// Interpolated identities (see Error.With) resolve to their sentinel.
+func sentinelOf(err error) (Error, bool) {
+	var identity error
+
+	if levels, tail := unfold(err); len(levels) > 0 {
+		identity = levels[0].error
+	} else if tail != nil {
+		identity = tail
+	}
+
+	var sentinel Error
+	if identity == nil || !errors.As(identity, &sentinel) {
+		return "", false
+	}
+
+	return sentinel, true
+}
+
+// unfold walks the chain from the outermost level down and returns its xError levels
+// and the standard error terminating it (nil when the chain ends with an xError).
+// Levels with an empty (nil) identity are skipped, this is the policy every traversal helper shares.
+// A standard error without any ex chain inside terminates the chain right away, even if it wraps others.
+func unfold(err error) ([]*xError, error) {
+	if err != nil && !isEx(err) {
+		return nil, err
+	}
+
+	return walk(err)
+}
+
+// walk is unfold for any error: the fmt.Errorf wrappers are adopted as links (see Adopt) wherever they are.
+func walk(err error) ([]*xError, error) {
+	var levels []*xError
+
+	for err != nil {
+		xer, ok := step(err)
+		if !ok {
+			return levels, err
+		}
+
+		if xer.error != nil {
+			levels = append(levels, xer)
+		}
+
+		err = xer.because()
+	}
+
+	return levels, nil
+}
+
+// step returns the xError of the chain level: the level itself, the level adopted from a fmt.Errorf wrapper
+// (see Adopt) or, as the fallback for other foreign wrappers, the first xError they wrap.
+func step(err error) (*xError, bool) {
+	if xer, ok := err.(*xError); ok {
+		return xer, true
+	}
+
+	if xer, ok := adoptLevel(err); ok {
+		return xer, true
+	}
+
+	var xer *xError
+	if errors.As(err, &xer) {
+		return xer, true
+	}
+
+	return nil, false
+}
+
+// fold rebuilds the chain from its levels and the terminating error.
+// Every level is copied, so the original chain stays untouched.
+func fold(levels []*xError, tail error) error {
+	cause := tail
+
+	for i := len(levels) - 1; i >= 0; i-- {
+		level := *levels[i]
+		level.cause = cause
+		level.text = ""
+		cause = &level
+	}
+
+	return cause
+}
+
+// messages returns the messages of the chain links prepared for the output with prepare.
+// Empty messages are skipped, the same as the Error method does. The block of Error.ReasonBlock
+// is only truncated, as the Error method keeps its newlines, and it is reported to be the last message.
+func messages(err error, prepare func(message string) string) ([]string, bool) {
+	levels, tail := unfold(hooked(err))
+
+	list := make([]string, 0, len(levels)+1)
+
+	for _, level := range levels {
+		if message := level.error.Error(); message != "" {
+			list = append(list, prepare(message))
+		}
+	}
+
+	isBlock := false
+
+	if text, ok := tail.(block); ok && text != "" {
+		list = append(list, truncate(string(text)))
+		isBlock = true
+	} else if tail != nil {
+		if message := tail.Error(); message != "" {
+			list = append(list, prepare(message))
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, false
+	}
+
+	return list, isBlock
+}
+
+// join joins the messages with Separator the same as the Error method does: the block of Error.ReasonBlock
+// (reported by messages) starts on its own line.
+func join(list []string, isBlock bool) string {
+	if !isBlock || len(list) < 2 {
+		return strings.Join(list, Separator)
+	}
+
+	last := len(list) - 1
+
+	return strings.Join(list[:last], Separator) + strings.TrimRight(Separator, " ") + "\n" + list[last]
+}
@@ -0,0 +1,900 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	var (
+		ioErr    = errors.New("connection reset")
+		otherErr = errors.New("other")
+		wrapped  = fmt.Errorf("query: %w", ioErr)
+		chain    = userErr.Because(dbErr.Because(ioErr))
+	)
+
+	type args struct {
+		err    error
+		target error
+	}
+
+	type want struct {
+		match bool
+		is    bool
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want want
+	}{
+		{name: "chain has identity", args: args{err: chain, target: dbErr}, want: want{match: true, is: true}},
+		{name: "chain has cause", args: args{err: chain, target: ioErr}, want: want{match: true, is: true}},
+		{name: "cause in chain", args: args{err: ioErr, target: chain}, want: want{match: true, is: false}},
+		{name: "identity in chain", args: args{err: dbErr, target: chain}, want: want{match: true, is: false}},
+		{name: "unrelated", args: args{err: otherErr, target: chain}, want: want{match: false, is: false}},
+		{name: "standard wrapper", args: args{err: wrapped, target: ioErr}, want: want{match: true, is: true}},
+		{name: "standard reversed", args: args{err: ioErr, target: wrapped}, want: want{match: false, is: false}},
+		{name: "nil", args: args{err: nil, target: nil}, want: want{match: true, is: true}},
+		{name: "nil target", args: args{err: chain, target: nil}, want: want{match: false, is: false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want.match, ex.Match(test.args.err, test.args.target))
+			require.Equal(t, test.want.is, errors.Is(test.args.err, test.args.target))
+		})
+	}
+}
+
+func TestDepthOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		repoErr = ex.Error("repository error")
+		dbErr   = ex.Error("database error")
+		cfgErr  = ex.Error("config error")
+	)
+
+	var (
+		ioErr = errors.New("connection reset")
+		err   = userErr.Because(repoErr.Because(dbErr.Because(fmt.Errorf("query: %w", ioErr))))
+	)
+
+	type args struct {
+		target error
+	}
+
+	type want struct {
+		depth int
+		ok    bool
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want want
+	}{
+		{name: "outermost", args: args{target: userErr}, want: want{depth: 0, ok: true}},
+		{name: "middle", args: args{target: dbErr}, want: want{depth: 2, ok: true}},
+		{name: "wrapped standard", args: args{target: ioErr}, want: want{depth: 3, ok: true}},
+		{name: "missing", args: args{target: cfgErr}, want: want{depth: 0, ok: false}},
+		{name: "nil target", args: args{target: nil}, want: want{depth: 0, ok: false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			depth, ok := ex.DepthOf(err, test.args.target)
+
+			require.Equal(t, test.want.ok, ok)
+			require.Equal(t, test.want.depth, depth)
+			require.Equal(t, errors.Is(err, test.args.target), ok)
+		})
+	}
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		depth, ok := ex.DepthOf(nil, userErr)
+
+		require.False(t, ok)
+		require.Zero(t, depth)
+	})
+}
+
+func TestTrim(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	var (
+		ioErr = errors.New("connection reset by peer")
+		err   = userErr.Because(dbErr.Because(ioErr))
+	)
+
+	t.Run("nothing to keep", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.Trim(err, 0))
+		require.NoError(t, ex.Trim(err, -1))
+		require.NoError(t, ex.Trim(nil, 1))
+	})
+
+	t.Run("truncated chain", func(t *testing.T) {
+		t.Parallel()
+
+		got := ex.Trim(err, 1)
+
+		require.EqualError(t, got, "user not found: …(2 more)")
+		require.ErrorIs(t, got, userErr)
+		require.NotErrorIs(t, got, dbErr)
+		require.NotErrorIs(t, got, ioErr)
+
+		got = ex.Trim(err, 2)
+
+		require.EqualError(t, got, "user not found: database error: …(1 more)")
+		require.ErrorIs(t, got, dbErr)
+		require.NotErrorIs(t, got, ioErr)
+	})
+
+	t.Run("exact depth", func(t *testing.T) {
+		t.Parallel()
+
+		got := ex.Trim(err, 3)
+
+		require.Equal(t, err, got)
+		require.NotSame(t, err, got)
+		require.ErrorIs(t, got, ioErr)
+	})
+
+	t.Run("deeper than chain", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, err, ex.Trim(err, 10))
+		require.Equal(t, ex.Conv(userErr), ex.Trim(ex.Conv(userErr), 10))
+		require.Same(t, ioErr, ex.Trim(ioErr, 1))
+	})
+}
+
+func TestSplitAt(t *testing.T) {
+	t.Parallel()
+
+	const (
+		ErrUserNotFound = ex.Error("user not found")
+		ErrDatabase     = ex.Error("database error")
+		ErrConfig       = ex.Error("bad config")
+	)
+
+	var (
+		ioErr = errors.New("connection reset by peer")
+		dbErr = ex.WithField(ErrDatabase.Because(ioErr), "table", "users")
+		err   = ex.WithField(ErrUserNotFound.Because(dbErr), "user", 42)
+	)
+
+	t.Run("split", func(t *testing.T) {
+		t.Parallel()
+
+		outer, inner := ex.SplitAt(err, ErrDatabase)
+
+		require.EqualError(t, outer, "user not found: database error")
+		require.ErrorIs(t, outer, ErrUserNotFound)
+		require.ErrorIs(t, outer, ErrDatabase)
+		require.NotErrorIs(t, outer, ioErr)
+		require.Equal(t, map[string]any{"user": 42, "table": "users"}, ex.Fields(outer))
+		require.Equal(t, ioErr, inner)
+		require.EqualError(t, err, "user not found: database error: connection reset by peer")
+	})
+
+	t.Run("split at the top", func(t *testing.T) {
+		t.Parallel()
+
+		outer, inner := ex.SplitAt(err, ErrUserNotFound)
+
+		require.EqualError(t, outer, "user not found")
+		require.EqualError(t, inner, "database error: connection reset by peer")
+		require.ErrorIs(t, inner, ErrDatabase)
+		require.NotErrorIs(t, inner, ErrUserNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		outer, inner := ex.SplitAt(err, ErrConfig)
+
+		require.Same(t, err, outer)
+		require.NoError(t, inner)
+
+		outer, inner = ex.SplitAt(ioErr, ErrDatabase)
+
+		require.Equal(t, ioErr, outer)
+		require.NoError(t, inner)
+
+		outer, inner = ex.SplitAt(nil, ErrDatabase)
+
+		require.NoError(t, outer)
+		require.NoError(t, inner)
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	timeout := errors.New("timeout")
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+	}{
+		{name: "standard leaf", args: args{err: userErr.Because(dbErr.Because(timeout))}},
+		{name: "reason leaf", args: args{err: userErr.Because(dbErr.Reason("no rows"))}},
+		{name: "no cause", args: args{err: ex.Conv(userErr)}},
+		{name: "sentinel", args: args{err: userErr}},
+		{name: "standard error", args: args{err: timeout}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.Normalize(test.args.err)
+
+			require.Equal(t, test.args.err.Error(), got.Error())
+
+			for _, link := range ex.ExposeAll(test.args.err) {
+				require.ErrorIs(t, got, link.Identity)
+			}
+
+			for _, link := range ex.ExposeAll(got) {
+				require.False(t, link.Foreign)
+			}
+		})
+	}
+
+	t.Run("keeps metadata", func(t *testing.T) {
+		t.Parallel()
+
+		got := ex.Normalize(ex.WithAttempts(userErr.Because(timeout), 3))
+
+		attempts, ok := ex.Attempts(got)
+
+		require.True(t, ok)
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, ex.Normalize(nil))
+	})
+}
+
+func TestExposeAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, ex.ExposeAll(nil))
+	})
+
+	t.Run("standard passed", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.New("not an ex")
+
+		require.Equal(t, []ex.Link{{Identity: err, Cause: nil, Foreign: true}}, ex.ExposeAll(err))
+	})
+
+	t.Run("deep chain", func(t *testing.T) {
+		t.Parallel()
+
+		const depth = 15
+
+		var err error = ex.Error("root")
+		for i := 1; i <= depth; i++ {
+			level := "level " + string(rune('a'+i))
+			err = ex.Conv(ex.Error(level)).Because(err)
+		}
+
+		links := ex.ExposeAll(err)
+
+		require.Len(t, links, depth+1)
+		require.Equal(t, ex.Error("level p"), links[0].Identity)
+		require.Equal(t, ex.Error("level b"), links[depth-1].Identity)
+		require.Equal(t, ex.Link{Identity: ex.Error("root"), Cause: nil, Foreign: true}, links[depth])
+
+		for _, link := range links[:depth] {
+			require.False(t, link.Foreign)
+			require.Error(t, link.Cause)
+		}
+	})
+
+	t.Run("interrupted chain", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			userErr = ex.Error("user not found")
+			dbErr   = ex.Error("database error")
+		)
+
+		var (
+			ioErr   = errors.New("connection reset by peer")
+			wrapped = fmt.Errorf("query: %w", dbErr.Because(ioErr))
+			err     = userErr.Because(wrapped)
+			links   = ex.ExposeAll(err)
+		)
+
+		// the fmt.Errorf level is adopted as a link showing its own prefix
+		require.Len(t, links, 4)
+		require.Equal(t, ex.Link{Identity: userErr, Cause: wrapped, Foreign: false}, links[0])
+		require.EqualError(t, links[1].Identity, "query")
+		require.ErrorIs(t, links[1].Identity, wrapped)
+		require.Equal(t, dbErr.Because(ioErr), links[1].Cause)
+		require.Equal(t, ex.Link{Identity: dbErr, Cause: ioErr, Foreign: false}, links[2])
+		require.Equal(t, ex.Link{Identity: ioErr, Cause: nil, Foreign: true}, links[3])
+	})
+}
+
+func TestSharesIdentity(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiErr     = ex.Error("api error")
+		billingErr = ex.Error("billing error")
+		dbErr      = ex.Error("database error")
+		authErr    = ex.Error("auth error")
+	)
+
+	var (
+		ioErr   = errors.New("connection reset by peer")
+		apiSide = apiErr.Because(dbErr.Because(ioErr))
+		billing = billingErr.Because(dbErr.Because(ioErr))
+		auth    = authErr.Reason("token expired")
+	)
+
+	type args struct {
+		a error
+		b error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want bool
+	}{
+		{name: "shared middle identity", args: args{a: apiSide, b: billing}, want: true},
+		{name: "shared middle identity reversed", args: args{a: billing, b: apiSide}, want: true},
+		{name: "disjoint chains", args: args{a: apiSide, b: auth}, want: false},
+		{name: "standard only chain", args: args{a: apiSide, b: ioErr}, want: false},
+		{name: "standard only chains", args: args{a: ioErr, b: ioErr}, want: false},
+		{name: "nothing passed", args: args{a: nil, b: nil}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.SharesIdentity(test.args.a, test.args.b)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestEqualXError(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiErr = ex.Error("api error")
+		dbErr  = ex.Error("database error")
+	)
+
+	// built independently: different cause types, metadata and construction
+	var (
+		built  = ex.Conv(apiErr.Because(dbErr.Because(errors.New("timeout"))))
+		chain  = ex.Conv(ex.WithField(apiErr.Chain(dbErr, ex.Error("timeout")), "id", 42))
+		reason = ex.Conv(apiErr.Because(dbErr.Reason("timeout")))
+	)
+
+	type args struct {
+		a ex.XError
+		b ex.XError
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want bool
+	}{
+		{name: "built independently", args: args{a: built, b: chain}, want: true},
+		{name: "reason cause", args: args{a: built, b: reason}, want: true},
+		{name: "nil identity", args: args{a: built, b: ex.Conv(ex.OnlyCause(built))}, want: true},
+		{
+			name: "different cause",
+			args: args{a: built, b: ex.Conv(apiErr.Because(dbErr.Reason("refused")))},
+			want: false,
+		},
+		{
+			name: "different identity",
+			args: args{a: built, b: ex.Conv(apiErr.Because(apiErr.Reason("timeout")))},
+			want: false,
+		},
+		{name: "different length", args: args{a: built, b: ex.Conv(apiErr.Reason("timeout"))}, want: false},
+		{
+			name: "cause or identity",
+			args: args{a: ex.Conv(apiErr.Because(dbErr)), b: ex.Conv(apiErr.Because(ex.Conv(dbErr)))},
+			want: false,
+		},
+		{name: "one nil", args: args{a: built, b: nil}, want: false},
+		{name: "nothing passed", args: args{a: nil, b: nil}, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.EqualXError(test.args.a, test.args.b)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	ioErr := errors.New("connection reset")
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want []string
+	}{
+		{
+			name: "mixed chain",
+			args: args{err: userErr.Because(dbErr.Because(ioErr))},
+			want: []string{"user not found", "database error", "connection reset"},
+		},
+		{
+			name: "reason chain",
+			args: args{err: ex.Conv(userErr).Because(dbErr.Reason("no rows"))},
+			want: []string{"user not found", "database error", "no rows"},
+		},
+		{
+			name: "no cause",
+			args: args{err: ex.Conv(userErr)},
+			want: []string{"user not found"},
+		},
+		{
+			name: "standard error",
+			args: args{err: ioErr},
+			want: []string{"connection reset"},
+		},
+		{
+			name: "nothing passed",
+			args: args{err: nil},
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.Flatten(test.args.err)
+
+			require.Equal(t, test.want, got)
+
+			if test.args.err != nil {
+				require.Equal(t, test.args.err.Error(), strings.Join(got, ex.Separator))
+			}
+		})
+	}
+}
+
+func TestCauseString(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{
+			name: "standard cause",
+			args: args{err: userErr.Because(dbErr.Because(errors.New("timeout")))},
+			want: "timeout",
+		},
+		{name: "reason cause", args: args{err: dbErr.Reason("no rows")}, want: "no rows"},
+		{name: "xerror cause", args: args{err: ex.Conv(userErr).Because(ex.Conv(dbErr))}, want: "database error"},
+		{name: "no cause", args: args{err: ex.Conv(userErr)}, want: ""},
+		{name: "standard error", args: args{err: errors.New("timeout")}, want: "timeout"},
+		{name: "nothing passed", args: args{err: nil}, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.CauseString(test.args.err)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestDistinctRootCauses(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr  = ex.Error("user not found")
+		orderErr = ex.Error("order failed")
+		dbErr    = ex.Error("database error")
+	)
+
+	resetErr := errors.New("connection reset")
+
+	type args struct {
+		errs []error
+	}
+
+	tests := []struct {
+		want map[string]int
+		name string
+		args args
+	}{
+		{
+			name: "shared and distinct",
+			args: args{errs: []error{
+				userErr.Because(dbErr.Because(resetErr)),
+				orderErr.Because(dbErr.Because(resetErr)),
+				orderErr.Because(resetErr),
+				orderErr.Reason("out of stock"),
+				errors.New("out of stock"),
+				nil,
+			}},
+			want: map[string]int{"connection reset": 3, "out of stock": 2},
+		},
+		{
+			name: "without cause",
+			args: args{errs: []error{userErr, ex.Conv(userErr), dbErr.Because(userErr)}},
+			want: map[string]int{"user not found": 3},
+		},
+		{name: "nothing passed", args: args{errs: nil}, want: map[string]int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.DistinctRootCauses(test.args.errs)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestEmptyIdentityPolicy(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	var (
+		ioErr = errors.New("connection reset")
+		err   = userErr.Because(ex.OnlyCause(dbErr.Because(ex.OnlyCause(ioErr))))
+		want  = userErr.Because(dbErr.Because(ioErr))
+	)
+
+	require.EqualError(t, err, "user not found: database error: connection reset")
+	require.EqualError(t, ex.OnlyCause(ioErr), "connection reset")
+	require.Equal(t, ex.Flatten(want), ex.Flatten(err))
+	require.Equal(t, ex.Breadcrumbs(want), ex.Breadcrumbs(err))
+	require.Equal(t, ex.Dump(want), ex.Dump(err))
+	require.Equal(t, ex.CauseString(want), ex.CauseString(err))
+	require.Equal(t, ex.Trim(want, 2).Error(), ex.Trim(err, 2).Error())
+	require.True(t, ex.SharesIdentity(err, want))
+	require.False(t, ex.SharesIdentity(ex.OnlyCause(ioErr), ex.OnlyCause(ioErr)))
+
+	links := ex.ExposeAll(err)
+
+	require.Len(t, links, 3)
+	require.Equal(t, userErr, links[0].Identity)
+	require.Equal(t, dbErr, links[1].Identity)
+	require.Equal(t, ioErr, links[2].Identity)
+}
+
+func TestTopN(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	err := userErr.Because(dbErr.Because(errors.New("connection reset by peer")))
+
+	type args struct {
+		err error
+		n   int
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{name: "truncated", args: args{err: err, n: 2}, want: "user not found: database error: …"},
+		{
+			name: "exact chain",
+			args: args{err: err, n: 3},
+			want: "user not found: database error: connection reset by peer",
+		},
+		{name: "shorter chain", args: args{err: dbErr.Reason("timeout"), n: 5}, want: "database error: timeout"},
+		{name: "standard error", args: args{err: errors.New("timeout"), n: 1}, want: "timeout"},
+		{name: "not positive", args: args{err: err, n: 0}, want: ""},
+		{name: "nothing passed", args: args{err: nil, n: 1}, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.TopN(test.args.err, test.args.n)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestStringDedup(t *testing.T) {
+	t.Parallel()
+
+	const (
+		retryErr   = ex.Error("retry failed")
+		timeoutErr = ex.Error("timeout")
+	)
+
+	reset := errors.New("connection reset")
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{
+			name: "consecutive duplicates",
+			args: args{err: timeoutErr.Because(timeoutErr.Because(timeoutErr.Because(reset)))},
+			want: "timeout (x3): connection reset",
+		},
+		{
+			name: "distinct segments",
+			args: args{err: retryErr.Because(timeoutErr.Because(timeoutErr.Because(retryErr.Because(timeoutErr))))},
+			want: "retry failed: timeout (x2): retry failed: timeout",
+		},
+		{
+			name: "no duplicates",
+			args: args{err: retryErr.Because(timeoutErr.Because(reset))},
+			want: "retry failed: timeout: connection reset",
+		},
+		{name: "standard error", args: args{err: reset}, want: "connection reset"},
+		{name: "nothing passed", args: args{err: nil}, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, ex.StringDedup(test.args.err))
+		})
+	}
+
+	t.Run("chain untouched", func(t *testing.T) {
+		t.Parallel()
+
+		err := timeoutErr.Because(timeoutErr.Because(reset))
+
+		_ = ex.StringDedup(err)
+
+		require.EqualError(t, err, "timeout: timeout: connection reset")
+		require.ErrorIs(t, err, reset)
+	})
+}
+
+func TestTopIdentities(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiErr   = ex.Error("api error")
+		retryErr = ex.Error("retry failed")
+		dbErr    = ex.Error("database error")
+	)
+
+	err := apiErr.Because(retryErr.Because(retryErr.Because(dbErr.Because(errors.New("timeout")))))
+
+	type args struct {
+		err error
+		n   int
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want []string
+	}{
+		{name: "bounded", args: args{err: err, n: 2}, want: []string{"api error", "retry failed"}},
+		{
+			name: "deduplicated",
+			args: args{err: err, n: 3},
+			want: []string{"api error", "retry failed", "database error"},
+		},
+		{
+			name: "shorter chain",
+			args: args{err: err, n: 10},
+			want: []string{"api error", "retry failed", "database error"},
+		},
+		{name: "standard error", args: args{err: errors.New("timeout"), n: 1}, want: nil},
+		{name: "not positive", args: args{err: err, n: 0}, want: nil},
+		{name: "nothing passed", args: args{err: nil, n: 1}, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.TopIdentities(test.args.err, test.args.n)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestDedupePrefix(t *testing.T) {
+	t.Parallel()
+
+	const (
+		svcErr = ex.Error("svc")
+		dbErr  = ex.Error("db error")
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{
+			name: "repeated links",
+			args: args{err: svcErr.Because(svcErr.Because(svcErr.Because(dbErr)))},
+			want: "svc: db error",
+		},
+		{
+			name: "fmt prefixes",
+			args: args{err: svcErr.Because(fmt.Errorf("svc: %w", fmt.Errorf("svc: %w", dbErr)))},
+			want: "svc: db error",
+		},
+		{
+			name: "distinct prefixes",
+			args: args{err: ex.Error("api").Because(svcErr.Because(dbErr))},
+			want: "api: svc: db error",
+		},
+		{
+			name: "repeated further down",
+			args: args{err: ex.Error("api").Because(svcErr.Because(svcErr.Because(dbErr)))},
+			want: "api: svc: svc: db error",
+		},
+		{name: "only prefixes", args: args{err: svcErr.Because(svcErr)}, want: "svc"},
+		{name: "single link", args: args{err: dbErr}, want: "db error"},
+		{name: "nothing passed", args: args{err: nil}, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.DedupePrefix(test.args.err)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestLabelKey(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr  = ex.Error("user not found")
+		httpErr  = ex.Error("  HTTP/2 Stream-Reset (code 7)!  ")
+		greetErr = ex.Error("hello %s")
+		emptyErr = ex.Error("")
+		dashErr  = ex.Error("--")
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{name: "multi-word", args: args{err: userErr.Reason("id 42")}, want: "user_not_found"},
+		{name: "punctuation", args: args{err: httpErr}, want: "http_2_stream_reset_code_7"},
+		{name: "interpolated", args: args{err: greetErr.With("world")}, want: "hello_s"},
+		{name: "outermost", args: args{err: ex.Unexpected(userErr)}, want: "unexpected"},
+		{name: "non-ascii", args: args{err: ex.Error("café déjà vu")}, want: "caf_d_j_vu"},
+		{name: "nil identity", args: args{err: ex.OnlyCause(userErr.Reason("id 42"))}, want: "user_not_found"},
+		{name: "no letters", args: args{err: dashErr.Reason("why")}, want: "unknown"},
+		{name: "empty", args: args{err: emptyErr}, want: "unknown"},
+		{name: "standard error", args: args{err: errors.New("user not found")}, want: "unknown"},
+		{name: "nothing passed", args: args{err: nil}, want: "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.LabelKey(test.args.err)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// exPath is the import path of the package declaring the Error type.
+const exPath = "github.com/therenotomorrow/ex"
+
+// errLoad represents a failure to load the packages.
+var errLoad = errors.New("load packages")
+
+// Entry documents an identity, the metadata that can't be determined statically is omitted.
+type Entry struct {
+	Package  string `json:"package"`
+	Name     string `json:"name"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+	Help     string `json:"help,omitempty"`
+	Hint     string `json:"hint,omitempty"`
+	Docs     string `json:"docs,omitempty"`
+	Owner    string `json:"owner,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Code     int    `json:"code,omitempty"`
+}
+
+// collect loads the packages matching the patterns from the directory and returns the entries
+// of their identities, sorted by the package and the name.
+func collect(dir string, patterns []string) ([]Entry, error) {
+	conf := &packages.Config{
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps |
+			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir: dir,
+	}
+
+	pkgs, err := packages.Load(conf, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errLoad, err)
+	}
+
+	var (
+		problems []string
+		entries  = make(map[types.Object]*Entry)
+	)
+
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, perr := range pkg.Errors {
+			problems = append(problems, perr.Error())
+		}
+	})
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", errLoad, strings.Join(problems, "; "))
+	}
+
+	// identities are declared first, so the registrations of every loaded package can refer to them
+	for _, pkg := range pkgs {
+		declarations(pkg, entries)
+	}
+
+	for _, pkg := range pkgs {
+		registrations(pkg, entries)
+	}
+
+	list := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		list = append(list, *entry)
+	}
+
+	slices.SortFunc(list, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(a.Package, b.Package), cmp.Compare(a.Name, b.Name))
+	})
+
+	return list, nil
+}
+
+// declarations adds the entries of the ex.Error constants declared in the package.
+func declarations(pkg *packages.Package, entries map[types.Object]*Entry) {
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				value, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				for _, name := range value.Names {
+					obj, ok := pkg.TypesInfo.Defs[name].(*types.Const)
+					if !ok || !isIdentity(obj.Type()) {
+						continue
+					}
+
+					entries[obj] = &Entry{
+						Package:  pkg.PkgPath,
+						Name:     obj.Name(),
+						Text:     constant.StringVal(obj.Val()),
+						Comment:  comment(gen, value),
+						Help:     "",
+						Hint:     "",
+						Docs:     "",
+						Owner:    "",
+						Severity: "",
+						Code:     0,
+					}
+				}
+			}
+		}
+	}
+}
+
+// registrations applies the ex.RegisterX calls of the package to the entries of their identities,
+// the calls with arguments that are not constants are skipped.
+func registrations(pkg *packages.Package, entries map[types.Object]*Entry) {
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+
+			fn, ok := referenced(pkg.TypesInfo, call.Fun).(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != exPath {
+				return true
+			}
+
+			entry, ok := entries[referenced(pkg.TypesInfo, call.Args[0])]
+			if !ok {
+				return true
+			}
+
+			value := pkg.TypesInfo.Types[call.Args[1]].Value
+			if value == nil {
+				return true
+			}
+
+			register(entry, fn.Name(), value)
+
+			return true
+		})
+	}
+}
+
+// register records the constant value of the registration on the entry.
+func register(entry *Entry, name string, value constant.Value) {
+	switch name {
+	case "RegisterCode":
+		if code, ok := constant.Int64Val(value); ok {
+			entry.Code = int(code)
+		}
+	case "RegisterSeverity":
+		if severity, ok := constant.Int64Val(value); ok {
+			entry.Severity = ex.Severity(severity).String() //nolint:gosec // severities are small constants
+		}
+	case "RegisterHelp":
+		entry.Help = stringVal(value)
+	case "RegisterHint":
+		entry.Hint = stringVal(value)
+	case "RegisterDocsURL":
+		entry.Docs = stringVal(value)
+	case "RegisterOwner":
+		entry.Owner = stringVal(value)
+	}
+}
+
+// stringVal returns the string of the constant, or the empty string for other kinds.
+func stringVal(value constant.Value) string {
+	if value.Kind() != constant.String {
+		return ""
+	}
+
+	return constant.StringVal(value)
+}
+
+// referenced returns the object the expression refers to, e.g. the constant of `ErrNotFound`
+// or the function of `ex.RegisterCode`.
+func referenced(info *types.Info, expr ast.Expr) types.Object {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return info.Uses[expr]
+	case *ast.SelectorExpr:
+		return info.Uses[expr.Sel]
+	default:
+		return nil
+	}
+}
+
+// isIdentity reports whether the type is ex.Error.
+func isIdentity(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == exPath && obj.Name() == "Error"
+}
+
+// comment returns the doc comment of the constant: its own one, the one of an ungrouped declaration
+// or, as the fallback, the trailing line comment.
+func comment(gen *ast.GenDecl, spec *ast.ValueSpec) string {
+	switch {
+	case spec.Doc != nil:
+		return strings.TrimSpace(spec.Doc.Text())
+	case !gen.Lparen.IsValid() && gen.Doc != nil:
+		return strings.TrimSpace(gen.Doc.Text())
+	case spec.Comment != nil:
+		return strings.TrimSpace(spec.Comment.Text())
+	default:
+		return ""
+	}
+}
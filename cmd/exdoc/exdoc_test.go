@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals // the flag of the go test command
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		format string
+	}
+
+	tests := []struct {
+		name   string
+		golden string
+		args   args
+	}{
+		{name: "markdown", golden: "fixture.md", args: args{format: "markdown"}},
+		{name: "json", golden: "fixture.json", args: args{format: "json"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout bytes.Buffer
+
+			err := run([]string{"-dir", "testdata/fixture", "-format", test.args.format, "."}, &stdout, io.Discard)
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", test.golden)
+
+			if *update {
+				require.NoError(t, os.WriteFile(golden, stdout.Bytes(), 0o600))
+			}
+
+			want, err := os.ReadFile(golden)
+
+			require.NoError(t, err)
+			require.Equal(t, string(want), stdout.String())
+		})
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		var first, second bytes.Buffer
+
+		require.NoError(t, run([]string{"-dir", "testdata/fixture"}, &first, io.Discard))
+		require.NoError(t, run([]string{"-dir", "testdata/fixture"}, &second, io.Discard))
+		require.Equal(t, first.String(), second.String())
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+
+		err := run([]string{"-format", "yaml"}, io.Discard, io.Discard)
+
+		require.ErrorIs(t, err, errFormat)
+		require.EqualError(t, err, `unsupported format: "yaml"`)
+	})
+
+	t.Run("broken package", func(t *testing.T) {
+		t.Parallel()
+
+		err := run([]string{"-dir", "testdata/missing", "."}, io.Discard, io.Discard)
+
+		require.ErrorIs(t, err, errLoad)
+	})
+
+	t.Run("bad flag", func(t *testing.T) {
+		t.Parallel()
+
+		var stderr bytes.Buffer
+
+		require.Error(t, run([]string{"-unknown"}, io.Discard, &stderr))
+		require.Contains(t, stderr.String(), "usage: exdoc")
+	})
+}
@@ -0,0 +1,19 @@
+module github.com/therenotomorrow/ex/cmd/exdoc
+
+go 1.25
+
+replace github.com/therenotomorrow/ex => ../..
+
+require (
+	github.com/stretchr/testify v1.11.1
+	github.com/therenotomorrow/ex v0.0.0
+	golang.org/x/tools v0.39.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.30.0 // indirect
+	golang.org/x/sync v0.18.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
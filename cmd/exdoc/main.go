@@ -0,0 +1,66 @@
+// Command exdoc generates the documentation of the ex.Error identities declared in Go packages.
+//
+// It finds the constants of the ex.Error type with their doc comments, along with the metadata
+// registered for them by the calls it can statically resolve (like ex.RegisterCode(ErrNotFound, 404)),
+// and prints the Markdown (or JSON) documentation to the standard output:
+//
+//	exdoc -dir ./service -format markdown ./...
+//
+// The output is deterministic: packages and identities are sorted, so it can be committed and diffed.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errFormat represents an unsupported output format.
+var errFormat = errors.New("unsupported format")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "exdoc:", err)
+
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, collects the identities and writes the documentation to stdout.
+func run(args []string, stdout, stderr io.Writer) error {
+	var (
+		flags  = flag.NewFlagSet("exdoc", flag.ContinueOnError)
+		dir    = flags.String("dir", ".", "the directory to load the packages from")
+		format = flags.String("format", "markdown", "the output format: markdown or json")
+	)
+
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "usage: exdoc [-dir directory] [-format markdown|json] [packages]")
+
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	render, ok := renderers[*format]
+	if !ok {
+		return fmt.Errorf("%w: %q", errFormat, *format)
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	entries, err := collect(*dir, patterns)
+	if err != nil {
+		return err
+	}
+
+	return render(stdout, entries)
+}
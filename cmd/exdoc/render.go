@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// renderers maps the output formats to their renderers.
+//
+//nolint:gochecknoglobals // read-only table
+var renderers = map[string]func(w io.Writer, entries []Entry) error{
+	"markdown": renderMarkdown,
+	"json":     renderJSON,
+}
+
+// renderJSON writes the entries as an indented JSON array.
+func renderJSON(w io.Writer, entries []Entry) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(entries)
+}
+
+// renderMarkdown writes the entries as a Markdown document with a section per package.
+func renderMarkdown(w io.Writer, entries []Entry) error {
+	var builder strings.Builder
+
+	builder.WriteString("# Errors\n")
+
+	for i, entry := range entries {
+		if i == 0 || entries[i-1].Package != entry.Package {
+			builder.WriteString("\n## " + entry.Package + "\n")
+		}
+
+		builder.WriteString("\n### " + entry.Name + "\n\n`" + entry.Text + "`\n")
+
+		if entry.Comment != "" {
+			builder.WriteString("\n" + entry.Comment + "\n")
+		}
+
+		details := []struct {
+			label string
+			value string
+		}{
+			{label: "Code", value: ""},
+			{label: "Severity", value: entry.Severity},
+			{label: "Owner", value: entry.Owner},
+			{label: "Help", value: entry.Help},
+			{label: "Hint", value: entry.Hint},
+			{label: "Docs", value: entry.Docs},
+		}
+
+		if entry.Code != 0 {
+			details[0].value = strconv.Itoa(entry.Code)
+		}
+
+		list := false
+
+		for _, detail := range details {
+			if detail.value == "" {
+				continue
+			}
+
+			if !list {
+				builder.WriteString("\n")
+
+				list = true
+			}
+
+			builder.WriteString("- " + detail.label + ": " + detail.value + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
@@ -0,0 +1,45 @@
+// Package fixture declares identities for the golden tests of exdoc.
+package fixture
+
+import "github.com/therenotomorrow/ex"
+
+// ErrNotFound is returned when the user doesn't exist.
+const ErrNotFound ex.Error = "user not found"
+
+// Storage errors.
+const (
+	// ErrDatabase wraps every failure of the database driver.
+	ErrDatabase = ex.Error("database error")
+
+	ErrTimeout ex.Error = "query timed out" // ErrTimeout is returned when the query exceeds its deadline.
+
+	ErrUndocumented ex.Error = "undocumented"
+)
+
+const (
+	// ErrConflict and ErrGone share the doc comment.
+	ErrConflict, ErrGone ex.Error = "conflict", "gone"
+
+	// notAnIdentity is a plain string constant, it is skipped.
+	notAnIdentity = "not an identity"
+)
+
+// owner is the team owning the storage identities.
+const owner = "storage-team"
+
+func init() {
+	ex.RegisterCode(ErrNotFound, 404)
+	ex.RegisterDocsURL(ErrNotFound, "https://example.com/errors/not-found")
+	ex.RegisterOwner(ErrDatabase, owner)
+	ex.RegisterSeverity(ErrDatabase, ex.SeverityError)
+	ex.RegisterHint(ErrTimeout, "retry with a larger deadline")
+	ex.RegisterCode(ErrConflict, 409)
+	ex.RegisterHelp(ErrGone, help())
+
+	_ = notAnIdentity
+}
+
+// help returns the help text, it is not a constant, so exdoc can't see it.
+func help() string {
+	return "the resource was deleted"
+}
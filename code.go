@@ -0,0 +1,85 @@
+package ex
+
+// codeKey is the metadata key of the error code.
+type codeKey struct{}
+
+// Breadcrumb is a single step of the structured trail of what went wrong at each layer of the chain.
+type Breadcrumb struct {
+	Identity string // The message of the link.
+	Code     int    // The code of the link, zero when it has none.
+}
+
+// ErrorKey is a comparable key of the error, e.g. for maps and switches. Errors with the same identity
+// and code have equal keys regardless of their causes and metadata other than the code.
+type ErrorKey struct {
+	Identity string // The sentinel of the outermost link, empty for standard errors.
+	Code     int    // The code of the error, zero when it has none.
+}
+
+// KeyOf returns the key of the error: the sentinel of its outermost link (interpolated identities,
+// see Error.With, resolve to their sentinel) and the code of the error (see CodeOf).
+// A causeless identity, e.g. a bare Error, is keyed the same as the chains it is the outermost link of.
+// Standard errors (and nil) have no identity, so their key is the zero ErrorKey.
+func KeyOf(err error) ErrorKey {
+	sentinel, ok := sentinelOf(err)
+	if !ok {
+		return ErrorKey{Identity: "", Code: 0}
+	}
+
+	code, _ := CodeOf(err)
+
+	return ErrorKey{Identity: string(sentinel), Code: code}
+}
+
+// WithCode attaches the code to the outermost link of the error, e.g. the status of an HTTP response.
+// The attached code wins over the one registered against the identity.
+func WithCode(err error, code int) error {
+	return attach(err, codeKey{}, code)
+}
+
+// RegisterCode registers the code of the identity, e.g. for API error responses.
+// Every link of a chain with the identity inherits the code.
+func RegisterCode(identity Error, code int) {
+	register(identity, codeKey{}, code)
+}
+
+// CodeOf returns the code of the nearest link of the error chain that has one.
+func CodeOf(err error) (int, bool) {
+	return lookup[int](err, codeKey{})
+}
+
+// Breadcrumbs returns the links of the error chain with their codes, from the outermost down.
+// This gives clients a structured trail without parsing the flattened message.
+// Links without a code (like standard errors) have a zero code.
+// The chain is transformed with the hook set with SetRenderHook first.
+func Breadcrumbs(err error) []Breadcrumb {
+	levels, tail := unfold(hooked(err))
+
+	crumbs := make([]Breadcrumb, 0, len(levels)+1)
+
+	for _, level := range levels {
+		code, _ := level.find(codeKey{})
+		crumbs = append(crumbs, breadcrumb(level.error, code))
+	}
+
+	if tail != nil {
+		code, _ := registered(tail, codeKey{})
+		crumbs = append(crumbs, breadcrumb(tail, code))
+	}
+
+	if len(crumbs) == 0 {
+		return nil
+	}
+
+	return crumbs
+}
+
+// breadcrumb creates the breadcrumb of the link.
+func breadcrumb(identity error, code any) Breadcrumb {
+	crumb := Breadcrumb{Identity: identity.Error(), Code: 0}
+	if code, ok := code.(int); ok {
+		crumb.Code = code
+	}
+
+	return crumb
+}
@@ -0,0 +1,155 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestCodeOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		notFoundErr = ex.Error("code test: not found")
+		dbErr       = ex.Error("code test: database error")
+		plainErr    = ex.Error("code test: plain")
+	)
+
+	ex.RegisterCode(notFoundErr, 404)
+	ex.RegisterCode(dbErr, 503)
+
+	t.Run("registered", func(t *testing.T) {
+		t.Parallel()
+
+		code, ok := ex.CodeOf(notFoundErr.Because(dbErr.Reason("no rows")))
+
+		require.True(t, ok)
+		require.Equal(t, 404, code)
+
+		code, ok = ex.CodeOf(plainErr.Because(dbErr))
+
+		require.True(t, ok)
+		require.Equal(t, 503, code)
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), plainErr, plainErr.Reason("why")} {
+			code, ok := ex.CodeOf(err)
+
+			require.False(t, ok)
+			require.Zero(t, code)
+		}
+	})
+}
+
+func TestBreadcrumbs(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("breadcrumbs test: user not found")
+		dbErr   = ex.Error("breadcrumbs test: database error")
+		repoErr = ex.Error("breadcrumbs test: repository error")
+	)
+
+	ex.RegisterCode(userErr, 404)
+	ex.RegisterCode(dbErr, 503)
+
+	t.Run("mixed chain", func(t *testing.T) {
+		t.Parallel()
+
+		err := userErr.Because(repoErr.Because(dbErr.Because(errors.New("connection reset"))))
+
+		require.Equal(t, []ex.Breadcrumb{
+			{Identity: "breadcrumbs test: user not found", Code: 404},
+			{Identity: "breadcrumbs test: repository error", Code: 0},
+			{Identity: "breadcrumbs test: database error", Code: 503},
+			{Identity: "connection reset", Code: 0},
+		}, ex.Breadcrumbs(err))
+	})
+
+	t.Run("sentinel cause", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, []ex.Breadcrumb{
+			{Identity: "breadcrumbs test: repository error", Code: 0},
+			{Identity: "breadcrumbs test: database error", Code: 503},
+		}, ex.Breadcrumbs(repoErr.Because(dbErr)))
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, ex.Breadcrumbs(nil))
+	})
+}
+
+func TestKeyOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		notFoundErr = ex.Error("key test: not found")
+		userErr     = ex.Error("key test: user %d")
+		plainErr    = ex.Error("key test: plain")
+	)
+
+	ex.RegisterCode(notFoundErr, 404)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		want ex.ErrorKey
+		args args
+	}{
+		{
+			name: "registered code",
+			args: args{err: notFoundErr.Because(errors.New("no rows"))},
+			want: ex.ErrorKey{Identity: "key test: not found", Code: 404},
+		},
+		{
+			name: "causeless identity",
+			args: args{err: notFoundErr},
+			want: ex.ErrorKey{Identity: "key test: not found", Code: 404},
+		},
+		{
+			name: "interpolated",
+			args: args{err: userErr.With(42)},
+			want: ex.ErrorKey{Identity: "key test: user %d", Code: 0},
+		},
+		{
+			name: "without code",
+			args: args{err: plainErr.Reason("why")},
+			want: ex.ErrorKey{Identity: "key test: plain", Code: 0},
+		},
+		{name: "standard error", args: args{err: errors.New("standard")}, want: ex.ErrorKey{Identity: "", Code: 0}},
+		{name: "nothing passed", args: args{err: nil}, want: ex.ErrorKey{Identity: "", Code: 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.KeyOf(test.args.err)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("map key", func(t *testing.T) {
+		t.Parallel()
+
+		cache := map[ex.ErrorKey]string{
+			ex.KeyOf(notFoundErr.Reason("first")): "cached",
+		}
+
+		require.Equal(t, "cached", cache[ex.KeyOf(notFoundErr.Because(errors.New("second")))])
+		require.Equal(t, ex.KeyOf(notFoundErr), ex.KeyOf(notFoundErr.Reason("any")))
+	})
+}
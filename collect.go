@@ -0,0 +1,55 @@
+package ex
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// collectorKey is the context key of the collector.
+type collectorKey struct{}
+
+// collector holds the errors collected with CollectTo.
+type collector struct {
+	errs []error
+	mu   sync.Mutex
+}
+
+// WithCollector returns a copy of the context with a new error collector, so the recoverable problems found
+// deep in the call tree can be surfaced at the end without threading a collector through every signature.
+// A collector of the inner context shadows the outer one.
+func WithCollector(ctx context.Context) context.Context {
+	return context.WithValue(ctx, collectorKey{}, &collector{errs: nil, mu: sync.Mutex{}})
+}
+
+// CollectTo adds the error to the nearest collector of the context. It is safe for concurrent use.
+// If there is no collector or the error is nil, nothing happens.
+func CollectTo(ctx context.Context, err error) {
+	bucket, ok := ctx.Value(collectorKey{}).(*collector)
+	if !ok || err == nil {
+		return
+	}
+
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	bucket.errs = append(bucket.errs, err)
+}
+
+// Collected returns the errors of the nearest collector of the context as a List, in the order of collecting.
+// If there is no collector or it has no errors, the result error will be nil.
+func Collected(ctx context.Context) error {
+	bucket, ok := ctx.Value(collectorKey{}).(*collector)
+	if !ok {
+		return nil
+	}
+
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	if len(bucket.errs) == 0 {
+		return nil
+	}
+
+	return &List{errs: slices.Clone(bucket.errs)}
+}
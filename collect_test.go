@@ -0,0 +1,93 @@
+package ex_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestCollected(t *testing.T) {
+	t.Parallel()
+
+	const renderErr = ex.Error("render error")
+
+	var (
+		missingErr = renderErr.Reason("missing title")
+		brokenErr  = errors.New("broken link")
+	)
+
+	t.Run("collector", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := ex.WithCollector(t.Context())
+
+		require.NoError(t, ex.Collected(ctx))
+
+		ex.CollectTo(ctx, missingErr)
+		ex.CollectTo(ctx, nil)
+		ex.CollectTo(ctx, brokenErr)
+
+		err := ex.Collected(ctx)
+
+		var list *ex.List
+
+		require.ErrorAs(t, err, &list)
+		require.Equal(t, []error{missingErr, brokenErr}, list.Errors())
+		require.EqualError(t, err, "render error: missing title; broken link")
+		require.ErrorIs(t, err, renderErr)
+		require.ErrorIs(t, err, brokenErr)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+
+		outer := ex.WithCollector(t.Context())
+		inner := ex.WithCollector(outer)
+
+		ex.CollectTo(inner, missingErr)
+		ex.CollectTo(context.WithValue(inner, "key", "value"), brokenErr) //nolint:staticcheck // any key will do
+
+		require.NoError(t, ex.Collected(outer))
+		require.EqualError(t, ex.Collected(inner), "render error: missing title; broken link")
+	})
+
+	t.Run("no collector", func(t *testing.T) {
+		t.Parallel()
+
+		require.NotPanics(t, func() {
+			ex.CollectTo(t.Context(), missingErr)
+		})
+		require.NoError(t, ex.Collected(t.Context()))
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		const goroutines = 16
+
+		var (
+			ctx   = ex.WithCollector(t.Context())
+			group sync.WaitGroup
+		)
+
+		for range goroutines {
+			group.Go(func() {
+				ex.CollectTo(ctx, missingErr)
+
+				_ = ex.Collected(ctx)
+			})
+		}
+
+		group.Wait()
+
+		var list *ex.List
+
+		require.ErrorAs(t, ex.Collected(ctx), &list)
+		require.Len(t, list.Errors(), goroutines)
+	})
+}
@@ -0,0 +1,23 @@
+package ex
+
+// deprecatedKey is the metadata key marking the deprecated identities.
+type deprecatedKey struct{}
+
+// RegisterDeprecated registers the identity as deprecated: the service is going to stop returning it,
+// so the clients should stop relying on it. See IsDeprecated and Manifest.
+func RegisterDeprecated(identity Error) {
+	register(identity, deprecatedKey{}, true)
+}
+
+// IsDeprecated reports whether the outermost identity of the error was registered with RegisterDeprecated.
+// Only the outermost identity counts: the clients never see the identities it wraps.
+func IsDeprecated(err error) bool {
+	sentinel, ok := sentinelOf(err)
+	if !ok {
+		return false
+	}
+
+	_, ok = registered(sentinel, deprecatedKey{})
+
+	return ok
+}
@@ -0,0 +1,46 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestIsDeprecated(t *testing.T) {
+	t.Parallel()
+
+	const (
+		legacyErr   = ex.Error("deprecated test: legacy token")
+		checkoutErr = ex.Error("deprecated test: checkout failed")
+	)
+
+	ex.RegisterDeprecated(legacyErr)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want bool
+	}{
+		{name: "deprecated identity", args: args{err: legacyErr}, want: true},
+		{name: "deprecated chain", args: args{err: legacyErr.Reason("v1")}, want: true},
+		{name: "display identity", args: args{err: legacyErr.With("version", 1)}, want: true},
+		{name: "wrapped deprecated identity", args: args{err: checkoutErr.Because(legacyErr)}, want: false},
+		{name: "standard error", args: args{err: errors.New("deprecated test: legacy token")}, want: false},
+		{name: "nothing passed", args: args{err: nil}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, ex.IsDeprecated(test.args.err))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package ex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// Template creates a parameterized identity from the fmt format, e.g. Template("user %s not found").
+// It is the same as Error(format), spelled out for readability where the identity is declared.
+// Errors created from it with Error.With show the interpolated message but still match the identity.
+// Unlike the Error.Template method, it doesn't render anything itself.
+func Template(format string) Error {
+	return Error(format)
+}
+
+// AsIdentity creates the identity named after the type T, qualified with its package name,
+// e.g. AsIdentity[billing.QuotaError]() is Error("billing.QuotaError"). It ties the identity to the type,
+// so renaming the type renames the identity too. Pointer, slice and other composite types keep their
+// notation, e.g. "*billing.QuotaError".
+func AsIdentity[T any]() Error {
+	return Error(reflect.TypeFor[T]().String())
+}
+
+// With creates a new xError, using the current Error as the root, which shows the Error interpolated
+// with the arguments as a fmt format. It still matches the current Error with errors.Is,
+// and the arguments are available with ArgsOf for structured logging.
+func (c Error) With(args ...any) error {
+	text := fmt.Sprintf(string(c), args...)
+
+	return &xError{error: &display{identity: c, text: text, args: args}, cause: nil, meta: nil, text: "", flags: 0}
+}
+
+// ArgsOf returns the arguments of the nearest link of the error chain created with Error.With.
+// If there is no such link, the result will be nil.
+func ArgsOf(err error) []any {
+	levels, _ := unfold(err)
+
+	for _, level := range levels {
+		var shown *display
+		if errors.As(level.error, &shown) && shown.args != nil {
+			return shown.args
+		}
+	}
+
+	return nil
+}
+
+// Template creates a new xError, using the current Error as the root, which is rendered as a text/template
+// with the data, e.g. Error("user {{.id}} not found").Template(map[string]any{"id": 42}).
+// The error shows the rendered message, but still matches the current Error with errors.Is.
+// If the template can't be parsed or executed, the result is an ErrUnexpected error.
+func (c Error) Template(data map[string]any) error {
+	tmpl, err := template.New("").Option("missingkey=error").Parse(string(c))
+	if err != nil {
+		return Unexpected(c.Because(err))
+	}
+
+	var builder strings.Builder
+	if err = tmpl.Execute(&builder, data); err != nil {
+		return Unexpected(c.Because(err))
+	}
+
+	return &xError{
+		error: &display{identity: c, text: builder.String(), args: nil},
+		cause: nil,
+		meta:  nil,
+		text:  "",
+		flags: 0,
+	}
+}
+
+// Display creates a new xError, using the current Error as the root, which shows the text instead of
+// the Error itself, e.g. to present a context-specific message. It still matches the current Error with errors.Is.
+func (c Error) Display(text string) error {
+	return &xError{error: &display{identity: c, text: text, args: nil}, cause: nil, meta: nil, text: "", flags: 0}
+}
+
+// display is an identity that shows a text other than its sentinel, while still matching it.
+type display struct {
+	identity Error  // The sentinel to match.
+	text     string // The text to show.
+	args     []any  // The arguments the text is interpolated with (can be nil).
+}
+
+// Error returns the text to show.
+func (d *display) Error() string {
+	return d.text
+}
+
+// Unwrap returns the sentinel, allowing compatibility with errors.Is and errors.As.
+func (d *display) Unwrap() error {
+	return d.identity
+}
@@ -0,0 +1,167 @@
+package ex_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestError_Template(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rendered message", func(t *testing.T) {
+		t.Parallel()
+
+		const userErr = ex.Error("user {{.id}} not found in {{.tenant}}")
+
+		var (
+			err        = userErr.Template(map[string]any{"id": 42, "tenant": "acme"})
+			got, cause = ex.Expose(err)
+		)
+
+		require.EqualError(t, err, "user 42 not found in acme")
+		require.ErrorIs(t, err, userErr)
+		require.ErrorIs(t, got, userErr)
+		require.NoError(t, cause)
+	})
+
+	t.Run("chained message", func(t *testing.T) {
+		t.Parallel()
+
+		const userErr = ex.Error("user {{.id}} not found")
+
+		var (
+			causeErr = errors.New("no rows")
+			err      = ex.Conv(userErr.Template(map[string]any{"id": 42})).Because(causeErr)
+		)
+
+		require.EqualError(t, err, "user 42 not found: no rows")
+		require.ErrorIs(t, err, userErr)
+		require.ErrorIs(t, err, causeErr)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		t.Parallel()
+
+		const brokenErr = ex.Error("user {{.id not found")
+
+		err := brokenErr.Template(map[string]any{"id": 42})
+
+		require.ErrorIs(t, err, ex.ErrUnexpected)
+		require.ErrorIs(t, err, brokenErr)
+	})
+
+	t.Run("missing data", func(t *testing.T) {
+		t.Parallel()
+
+		const userErr = ex.Error("user {{.id}} not found")
+
+		err := userErr.Template(nil)
+
+		require.ErrorIs(t, err, ex.ErrUnexpected)
+		require.ErrorIs(t, err, userErr)
+	})
+}
+
+func TestError_Display(t *testing.T) {
+	t.Parallel()
+
+	const userErr = ex.Error("user not found")
+
+	var (
+		causeErr   = errors.New("no rows")
+		err        = ex.Conv(userErr.Display("we couldn't find your account")).Because(causeErr)
+		got, cause = ex.Expose(err)
+	)
+
+	require.EqualError(t, err, "we couldn't find your account: no rows")
+	require.EqualError(t, got, "we couldn't find your account")
+	require.ErrorIs(t, err, userErr)
+	require.ErrorIs(t, got, userErr)
+	require.Equal(t, causeErr, cause)
+	require.EqualError(t, ex.Error("wrapper").Because(err), "wrapper: we couldn't find your account: no rows")
+}
+
+func TestTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpl := ex.Template("user %s not found in %d")
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			err     = tmpl.With("alice", 42)
+			wrapped = ex.Error("handler failed").Because(ex.Conv(err).Because(errors.New("no rows")))
+		)
+
+		require.EqualError(t, err, "user alice not found in 42")
+		require.EqualError(t, wrapped, "handler failed: user alice not found in 42: no rows")
+		require.ErrorIs(t, err, tmpl)
+		require.ErrorIs(t, wrapped, tmpl)
+		require.NotErrorIs(t, err, ex.Error("user alice not found in 42"))
+	})
+
+	t.Run("arguments", func(t *testing.T) {
+		t.Parallel()
+
+		wrapped := ex.Error("handler failed").Because(tmpl.With("bob", 7))
+
+		require.Equal(t, []any{"bob", 7}, ex.ArgsOf(wrapped))
+		require.Nil(t, ex.ArgsOf(tmpl.Reason("no args")))
+		require.Nil(t, ex.ArgsOf(errors.New("standard")))
+		require.Nil(t, ex.ArgsOf(nil))
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(ex.Conv(tmpl.With("carol", 1)).Reason("deleted"))
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"error":"user carol not found in 1","cause":{"error":"deleted"}}`, string(data))
+	})
+}
+
+type quotaError struct{}
+
+func TestAsIdentity(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		identity ex.Error
+	}
+
+	tests := []struct {
+		name string
+		want string
+		args args
+	}{
+		{name: "struct", args: args{identity: ex.AsIdentity[quotaError]()}, want: "ex_test.quotaError"},
+		{name: "pointer", args: args{identity: ex.AsIdentity[*quotaError]()}, want: "*ex_test.quotaError"},
+		{name: "ex type", args: args{identity: ex.AsIdentity[ex.Severity]()}, want: "ex.Severity"},
+		{name: "builtin", args: args{identity: ex.AsIdentity[int]()}, want: "int"},
+		{name: "interface", args: args{identity: ex.AsIdentity[error]()}, want: "error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, test.args.identity.Error())
+		})
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.AsIdentity[quotaError]().Because(errors.New("limit reached"))
+
+		require.EqualError(t, err, "ex_test.quotaError: limit reached")
+		require.ErrorIs(t, err, ex.AsIdentity[quotaError]())
+	})
+}
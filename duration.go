@@ -0,0 +1,36 @@
+package ex
+
+import "time"
+
+// durationKey is the metadata key of the operation duration.
+type durationKey struct{}
+
+// now returns the current time, replaced in tests.
+//
+//nolint:gochecknoglobals // the clock is replaced in tests
+var now = time.Now
+
+// WithDuration attaches the duration of the failed operation to the outermost link of the error,
+// e.g. "query timed out" after 4.98s. The duration is shown by Dump and JSON.
+func WithDuration(err error, d time.Duration) error {
+	return attach(err, durationKey{}, d)
+}
+
+// DurationOf returns the operation duration of the nearest link of the error chain that has one.
+func DurationOf(err error) (time.Duration, bool) {
+	return lookup[time.Duration](err, durationKey{})
+}
+
+// Timed attaches the time elapsed since the start to the error, for the deferred measuring pattern:
+//
+//	start := time.Now()
+//	defer func() { err = ex.Timed(start, err) }()
+//
+// If the error is nil, the result error will also be nil and nothing is measured.
+func Timed(start time.Time, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return WithDuration(err, now().Sub(start))
+}
@@ -0,0 +1,97 @@
+package ex_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestDurationOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		queryErr = ex.Error("query timed out")
+		loadErr  = ex.Error("load failed")
+	)
+
+	t.Run("survives wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = ex.WithDuration(queryErr.Because(errors.New("deadline")), 4980*time.Millisecond)
+			err   = loadErr.Because(inner)
+		)
+
+		duration, ok := ex.DurationOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, 4980*time.Millisecond, duration)
+		require.EqualError(t, err, "load failed: query timed out: deadline")
+		require.Equal(t, "load failed\nquery timed out\n    duration: 4.98s\ndeadline", ex.Dump(err))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.WithDuration(queryErr.Reason("deadline"), 1500*time.Millisecond)
+
+		data, mErr := json.Marshal(err)
+
+		require.NoError(t, mErr)
+		require.JSONEq(t, `{"error":"query timed out","duration":"1.5s","cause":{"error":"deadline"}}`, string(data))
+
+		got, uErr := ex.DecodeJSON(data)
+		duration, ok := ex.DurationOf(got)
+
+		require.NoError(t, uErr)
+		require.True(t, ok)
+		require.Equal(t, 1500*time.Millisecond, duration)
+		require.EqualError(t, got, "query timed out: deadline")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), queryErr.Reason("deadline")} {
+			duration, ok := ex.DurationOf(err)
+
+			require.False(t, ok)
+			require.Zero(t, duration)
+		}
+	})
+}
+
+//nolint:paralleltest // replaces the clock of the package
+func TestTimed(t *testing.T) {
+	const queryErr = ex.Error("query timed out")
+
+	start := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	restore := ex.SetClock(func() time.Time {
+		return start.Add(4980 * time.Millisecond)
+	})
+	defer restore()
+
+	query := func(fail bool) (err error) {
+		defer func() { err = ex.Timed(start, err) }()
+
+		if fail {
+			return queryErr.Reason("deadline")
+		}
+
+		return nil
+	}
+
+	err := query(true)
+	duration, ok := ex.DurationOf(ex.Unexpected(err))
+
+	require.True(t, ok)
+	require.Equal(t, 4980*time.Millisecond, duration)
+	require.EqualError(t, err, "query timed out: deadline")
+	require.NoError(t, query(false))
+}
@@ -2,9 +2,25 @@ package ex
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
+// Separator joins the messages of the chain links into a single error message.
+const Separator = ": "
+
+// defaultMatchDepth is the default limit of SetMaxMatchDepth.
+const defaultMatchDepth = 10_000
+
+// matchDepth is the limit set with SetMaxMatchDepth: zero means the default one, negative means no limit.
+//
+//nolint:gochecknoglobals // the limit is process-wide by design
+var matchDepth atomic.Int64
+
 const (
 	// ErrUnexpected represents an unexpected error, typically a bug or logic error.
 	ErrUnexpected Error = "unexpected"
@@ -14,6 +30,15 @@ const (
 
 	// ErrUnknown represents an unknown, non-registered error.
 	ErrUnknown Error = "unknown"
+
+	// ErrCloseFailed represents a failure to close a resource, see DeferClose.
+	ErrCloseFailed Error = "close failed"
+
+	// ErrInvalidField represents an invalid field of the map passed to FromFieldMap.
+	ErrInvalidField Error = "invalid field"
+
+	// ErrForbiddenIdentity represents a forbidden identity found in an error chain, see MustNotContain.
+	ErrForbiddenIdentity Error = "forbidden identity"
 )
 
 var (
@@ -39,10 +64,12 @@ func Conv(err error) XError {
 
 	var xer *xError
 	if errors.As(err, &xer) {
-		return &xError{error: xer.error, cause: xer.cause}
+		clone := *xer
+
+		return &clone
 	}
 
-	return &xError{error: err, cause: nil}
+	return &xError{error: err, cause: nil, meta: nil, text: "", flags: 0}
 }
 
 // New creates a new XError from the input text.
@@ -51,18 +78,133 @@ func New(text string) XError {
 		return nil
 	}
 
-	return &xError{error: Error(text), cause: nil}
+	return &xError{error: Error(text), cause: nil, meta: nil, text: "", flags: 0}
 }
 
 // Expose unwraps an error to reveal its internal components: the primary error and its cause.
 // If the error is standard - it returns the original error and nil as a cause.
+// It runs in constant time for ex errors: only the outermost link is read, whatever the depth of the chain.
+// The hook set with SetExposeHook is invoked for ex errors.
 func Expose(err error) (error, error) {
+	if xer, ok := err.(*xError); ok {
+		exposed(err)
+
+		return xer.error, xer.because()
+	}
+
 	var xer *xError
 	if !errors.As(err, &xer) {
 		return err, nil
 	}
 
-	return xer.error, xer.cause
+	exposed(err)
+
+	return xer.error, xer.because()
+}
+
+// Promote drops the outermost identity of the error and returns what was underneath it:
+// the cause, or the identity itself when there is no cause.
+// The outermost identity is found the way the other traversal helpers find it, so a fmt.Errorf wrapper
+// counts as the outermost link, e.g. Promote(fmt.Errorf("ctx: %w", ErrA.Because(ErrB))) is "a: b".
+// If the error is standard - it is returned untouched.
+func Promote(err error) error {
+	levels, tail := unfold(err)
+	if len(levels) == 0 {
+		return err
+	}
+
+	if rest := fold(levels[1:], tail); rest != nil {
+		return rest
+	}
+
+	return levels[0].error
+}
+
+// ReIdentify replaces the outermost identity of the error with the new one, preserving the cause chain
+// and the metadata of every link (fields, codes, stacks and so on), e.g. to map an internal identity
+// to the public one at an API boundary: ReIdentify(ErrDatabase.Because(ioErr), ErrUnavailable).
+// A bare identity is replaced as a whole, while a standard error becomes the cause of the new identity.
+// If the error is nil, the result error will also be nil.
+func ReIdentify(err error, identity Error) error {
+	if err == nil {
+		return nil
+	}
+
+	levels, tail := unfold(err)
+	if len(levels) == 0 {
+		if _, ok := tail.(Error); ok {
+			return identity
+		}
+
+		return identity.Because(tail)
+	}
+
+	top := *levels[0]
+	top.error = identity
+
+	return fold(append([]*xError{&top}, levels[1:]...), tail)
+}
+
+// AddCause appends the extra error to the innermost position of the chain, deepening it while preserving
+// every identity and its metadata. Unlike Because, which replaces the immediate cause of the outermost link
+// (and drops everything that was below it), AddCause keeps the whole chain and adds context at its leaf:
+// a standard error terminating the chain becomes a link with the extra error as its cause.
+//
+// If the extra error is nil or the error is frozen, the error is returned unchanged.
+// If the error is nil, the result error will also be nil.
+func AddCause(err, extra error) error {
+	if err == nil || extra == nil || IsFrozen(err) {
+		return err
+	}
+
+	levels, tail := unfold(err)
+	if tail != nil {
+		levels = append(levels, &xError{error: tail, cause: nil, meta: nil, text: "", flags: 0})
+	}
+
+	return fold(levels, extra)
+}
+
+// IsReason checks if the cause of the outermost xError was created from a text by Reason,
+// rather than set from an existing error by Because. This lets presentation code render
+// free-form reasons differently from structured system causes.
+func IsReason(err error) bool {
+	var xer *xError
+	if !errors.As(err, &xer) {
+		return false
+	}
+
+	return xer.flags&flagReason != 0
+}
+
+// Freeze returns a copy of the error whose Because and Reason methods return the error itself unchanged,
+// so the layers above an API boundary can't accidentally re-wrap it and add noise.
+// The frozen error still satisfies XError and matches errors.Is as before. Note that it can still become
+// the cause of another error, e.g. with Error.Because: only its own wrapping methods are turned off.
+// If the error is nil, the result error will also be nil.
+func Freeze(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	clone := xError{error: err, cause: nil, meta: nil, text: "", flags: 0}
+	if xer, ok := err.(*xError); ok {
+		clone = *xer
+	}
+
+	clone.flags |= flagFrozen
+
+	return &clone
+}
+
+// IsFrozen checks if the outermost xError of the error was frozen with Freeze.
+func IsFrozen(err error) bool {
+	var xer *xError
+	if !errors.As(err, &xer) {
+		return false
+	}
+
+	return xer.flags&flagFrozen != 0
 }
 
 // Panic panics if an error is present. Useful for handling critical situations that should halt execution.
@@ -75,6 +217,60 @@ func Panic(err error) {
 // of using default error handling mechanics.
 func Skip(_ error) {}
 
+// SkipIf is the conditional Skip: it returns nil when the error matches any of the targets with errors.Is,
+// deliberately ignoring the expected errors (like io.EOF or sql.ErrNoRows), otherwise it returns
+// the error unchanged for the normal handling.
+func SkipIf(err error, targets ...error) error {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// Handle calls fn with the error and reports true if an error is present, e.g.
+// `if ex.Handle(err, logIt) { continue }`. Useful for explicitly marking handled errors in loops.
+func Handle(err error, fn func(err error)) bool {
+	if err == nil {
+		return false
+	}
+
+	if fn != nil {
+		fn(err)
+	}
+
+	return true
+}
+
+// DeferClose closes the closer and records its failure into the error the pointer points to,
+// to be called as `defer ex.DeferClose(&err, f)` with err being the named result of the function.
+// The close error is wrapped under ErrCloseFailed, see Defer for the details.
+func DeferClose(err *error, closer io.Closer) {
+	Defer(err, ErrCloseFailed, closer.Close)
+}
+
+// Defer runs the cleanup function and records its failure into the error the pointer points to,
+// to be called as `defer ex.Defer(&err, ErrCleanup, cleanup)` with err being the named result of the function.
+// The cleanup error is wrapped under the identity: it becomes the error if there was none,
+// otherwise it is appended to the leaf of the existing error with AddCause, so both stay matchable with errors.Is
+// and the existing error keeps its identity.
+func Defer(errp *error, identity Error, fn func() error) {
+	cerr := fn()
+	if cerr == nil {
+		return
+	}
+
+	if *errp == nil {
+		*errp = identity.Because(cerr)
+
+		return
+	}
+
+	*errp = AddCause(*errp, identity.Because(cerr))
+}
+
 // Unexpected creates a new error with ErrUnexpected as the root and sets the cause.
 // If the cause is nil, the result error will also be nil.
 func Unexpected(cause error) error {
@@ -82,7 +278,43 @@ func Unexpected(cause error) error {
 		return nil
 	}
 
-	return &xError{error: ErrUnexpected, cause: cause}
+	return &xError{error: ErrUnexpected, cause: cause, meta: nil, text: "", flags: 0}
+}
+
+// OnlyIf returns the error unchanged if it matches any of the targets with errors.Is, otherwise it wraps
+// the error with Unexpected. It guards the contract of a module boundary: only the known identities
+// cross it as is, while unknown ones get tagged unexpected.
+// If the error is nil, the result error will also be nil.
+func OnlyIf(err error, targets ...error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return err
+		}
+	}
+
+	return Unexpected(err)
+}
+
+// MustNotContain guards a boundary the internal identities must never cross: it returns an error
+// with ErrForbiddenIdentity as the root if any of the forbidden identities matches the error with errors.Is.
+// The found identity is quoted in the reason, so the returned error doesn't match it itself.
+// If the chain is clean (or the error is nil), the result error will be nil.
+func MustNotContain(err error, forbidden ...Error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, identity := range forbidden {
+		if errors.Is(err, identity) {
+			return ErrForbiddenIdentity.Reason(strconv.Quote(string(identity)))
+		}
+	}
+
+	return nil
 }
 
 // Unknown creates a new error with ErrUnknown as the root and sets the cause.
@@ -92,17 +324,152 @@ func Unknown(cause error) error {
 		return nil
 	}
 
-	return &xError{error: ErrUnknown, cause: cause}
+	return &xError{error: ErrUnknown, cause: cause, meta: nil, text: "", flags: 0}
 }
 
 // Critical panics with a new error with ErrCritical as the root and sets the cause.
 // If the cause is nil, the result error will also be nil.
+// The panic site (the stack above the package) is captured onto the error,
+// so recover handlers can log the origin with Source, FirstStack or %+v.
+// Hooks registered with OnCritical are invoked right before the panic.
+// If panics are disabled with SetPanicMode, the zero value is returned instead of panicking.
 func Critical[T any](t T, cause error) T {
 	if cause == nil {
 		return t
 	}
 
-	panic(&xError{error: ErrCritical, cause: cause})
+	err := &xError{error: ErrCritical, cause: cause, meta: nil, text: "", flags: 0}
+	err.meta = err.meta.with(stackKey{}, external())
+
+	critical(err)
+
+	if panicDisabled.Load() {
+		var zero T
+
+		return zero
+	}
+
+	panic(err)
+}
+
+// FromPanic converts the value recovered from a panic into an error, e.g. `ex.FromPanic(recover())`.
+// Panics of Critical (and Panic) are returned as is, with ErrCritical as the root,
+// any other error or value becomes the cause of ErrUnexpected. See RecoverValue for the details.
+// If the value is nil, the result error will also be nil.
+func FromPanic(v any) error {
+	return recovered(ErrUnexpected, v)
+}
+
+// RecoverValue converts the value recovered from a panic into an error under ErrCritical,
+// e.g. `ex.RecoverValue(recover())`:
+//   - an error becomes the cause, still reachable with errors.Is and errors.As (like runtime.Error);
+//   - a string becomes the reason;
+//   - any other value becomes the reason formatted as "value (type)".
+//
+// Panics of Critical (and Panic) are returned as is, so they are not wrapped twice.
+// If the value is nil, the result error will also be nil.
+func RecoverValue(v any) error {
+	return recovered(ErrCritical, v)
+}
+
+// recovered converts the recovered value into an error under the root, the shared policy of FromPanic and RecoverValue.
+// The error is marked as recovered from a panic, see FromRecoveredPanic.
+func recovered(root Error, v any) error {
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case error:
+		if xer, ok := value.(*xError); ok && xer.error == ErrCritical {
+			clone := *xer
+			clone.flags |= flagPanic
+
+			return &clone
+		}
+
+		return &xError{error: root, cause: value, meta: nil, text: "", flags: flagPanic}
+	case string:
+		return &xError{error: root, cause: Error(value), meta: nil, text: "", flags: flagReason | flagPanic}
+	default:
+		text := fmt.Sprintf("%v (%T)", value, value)
+
+		return &xError{error: root, cause: Error(text), meta: nil, text: "", flags: flagReason | flagPanic}
+	}
+}
+
+// FromRecoveredPanic reports whether the error (or any link of its chain) was recovered from a panic
+// with FromPanic or RecoverValue. Panics often indicate bugs, so alerting can escalate such errors more
+// aggressively than the ordinary ones, even when both carry ErrUnexpected. The marker survives wrapping,
+// e.g. ErrHandler.Because(ex.FromPanic(recover())).
+func FromRecoveredPanic(err error) bool {
+	levels, _ := unfold(err)
+
+	for _, level := range levels {
+		if level.flags&flagPanic != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MustWith returns the value if there is no error, otherwise it panics like Critical
+// with the error wrapped under the identity. This gives context to panics in setup code.
+func MustWith[T any](identity Error, v T, err error) T {
+	if err == nil {
+		return v
+	}
+
+	return Critical(v, identity.Because(err))
+}
+
+// Chained builds the chain of the errors left-to-right, so every error becomes the cause of the previous one:
+// errs[0].Because(errs[1].Because(...)). Each error except the last is converted like Conv does,
+// which means its own cause (if any) is replaced by the rest of the chain, even for a frozen error:
+// its copy in the chain is not frozen. Nil errors are skipped.
+// If all errors are nil, the result error will also be nil. A single error is returned as is.
+func Chained(errs ...error) error {
+	var chain error
+
+	for i := len(errs) - 1; i >= 0; i-- {
+		switch {
+		case errs[i] == nil:
+			continue
+		case chain == nil:
+			chain = errs[i]
+		default:
+			chain = thaw(Conv(errs[i])).Because(chain)
+		}
+	}
+
+	return chain
+}
+
+// thaw returns a copy of the frozen error that is not frozen, so Chained can set its cause.
+func thaw(err XError) XError {
+	xer, ok := err.(*xError)
+	if !ok || xer.flags&flagFrozen == 0 {
+		return err
+	}
+
+	clone := *xer
+	clone.flags &^= flagFrozen
+
+	return &clone
+}
+
+// WrapAll nests the identities above the error, outermost-first, e.g. WrapAll(err, ErrBilling, ErrUpstream)
+// is the same as ErrBilling.Because(ErrUpstream.Because(err)), so the error carries all of them at once.
+// Without identities the error is returned unchanged. If the error is nil, the result error will also be nil.
+func WrapAll(err error, identities ...Error) error {
+	if err == nil {
+		return nil
+	}
+
+	for i := len(identities) - 1; i >= 0; i-- {
+		err = identities[i].Because(err)
+	}
+
+	return err
 }
 
 // Error is a constant string-based error type.
@@ -110,12 +477,28 @@ type Error string
 
 // Because creates a new xError, using the current Error as the root and setting the provided error as the cause.
 func (c Error) Because(cause error) error {
-	return &xError{error: c, cause: cause}
+	return &xError{error: c, cause: cause, meta: nil, text: "", flags: 0}
 }
 
 // Reason creates a new xError, using the current Error as the root and a new error from text as the cause.
 func (c Error) Reason(text string) error {
-	return &xError{error: c, cause: Error(text)}
+	cause, inline := reason(text)
+
+	return &xError{error: c, cause: cause, meta: nil, text: inline, flags: flagReason}
+}
+
+// ReasonBlock creates a new xError, using the current Error as the root and the text as a preformatted
+// block cause, e.g. the stderr of a command. Unlike Reason, the block keeps its newlines in the Error
+// message, starting on its own line, and Dump (and %+v) indents its lines under the link.
+func (c Error) ReasonBlock(text string) error {
+	return &xError{error: c, cause: block(strings.TrimRight(text, "\n")), meta: nil, text: "", flags: flagReason}
+}
+
+// Chain creates a new chain with the current Error as the root followed by the links, left-to-right,
+// e.g. ErrUser.Chain(ErrDatabase, ioErr) is the same as ErrUser.Because(ex.Conv(ErrDatabase).Because(ioErr)).
+// Nil links are skipped. See Chained for the details.
+func (c Error) Chain(links ...error) error {
+	return Chained(append([]error{c}, links...)...)
 }
 
 // Error returns the string representation of the Error, satisfying the standard error interface.
@@ -123,67 +506,267 @@ func (c Error) Error() string {
 	return string(c)
 }
 
+// block is a preformatted multi-line cause created by Error.ReasonBlock.
+type block string
+
+// Error returns the text of the block.
+func (b block) Error() string {
+	return string(b)
+}
+
 // xError is an implementation of XError that holds a primary error and a causal error.
 // This structure allows for creating a chain of errors to provide rich context.
 type xError struct {
-	error error // The primary error identity.
+	error error // The primary error identity (nil links are skipped by the traversal).
 	cause error // The underlying cause of the primary error (can be nil).
+	meta  *meta // The metadata attached to the error (can be nil).
+	text  Error // The cause created by Reason, kept unboxed while the cause is nil (see the because method).
+	flags flags // The internal markers of the error.
 }
 
-// Because creates a new xError, preserving the original primary error but replacing its cause.
+// flags are the internal markers of an xError.
+type flags uint8
+
+const (
+	// flagReason marks the cause as created from a text by Reason.
+	flagReason flags = 1 << iota
+	// flagFrozen marks the error as frozen by Freeze, so its Because and Reason are no-ops.
+	flagFrozen
+	// flagPanic marks the error as recovered from a panic by FromPanic or RecoverValue.
+	flagPanic
+	// flagHooked marks the copy of the error passed to the render hook, which renders without the hook.
+	flagHooked
+)
+
+// Because creates a new xError, preserving the original primary error and metadata but replacing its cause.
+// If the error is frozen, it is returned unchanged.
 func (e *xError) Because(cause error) error {
-	return &xError{error: e.error, cause: cause}
+	if e.flags&flagFrozen != 0 {
+		return e
+	}
+
+	clone := *e
+	clone.cause = cause
+	clone.text = ""
+	clone.flags &^= flagReason
+
+	return &clone
 }
 
-// Reason creates a new xError, preserving the original primary error
+// Reason creates a new xError, preserving the original primary error and metadata
 // but replacing its cause with a new error from text.
+// If the error is frozen, it is returned unchanged.
 func (e *xError) Reason(text string) error {
-	return &xError{error: e.error, cause: Error(text)}
+	if e.flags&flagFrozen != 0 {
+		return e
+	}
+
+	clone := *e
+	clone.cause, clone.text = reason(text)
+	clone.flags |= flagReason
+
+	return &clone
 }
 
 // Error flattens the error chain into a single, colon-separated string.
 // It recursively traverses the cause chain to build the final error message.
+// Links with an empty (nil) identity and links with an empty message are skipped,
+// so there is never a dangling separator and a fully empty chain renders as "".
+// The chain is transformed with the hook set with SetRenderHook first.
 func (e *xError) Error() string {
-	var builder strings.Builder
+	if renderHook.Load() != nil {
+		transformed := hooked(e)
+		if xer, ok := transformed.(*xError); ok {
+			return xer.message()
+		}
+
+		return transformed.Error()
+	}
+
+	return e.message()
+}
+
+// message renders the error chain for the Error method.
+func (e *xError) message() string {
+	// the common shape of Error.Reason: both sides are plain strings, so no builder is needed
+	if identity, ok := e.error.(Error); ok && identity != "" {
+		cause, ok := e.cause.(Error)
+		if e.cause == nil {
+			cause, ok = e.text, true
+		}
+
+		if ok && cause != "" {
+			return render(string(identity)) + Separator + render(string(cause))
+		}
+	}
 
-	builder.WriteString(e.error.Error())
+	var (
+		builder strings.Builder
+		written bool
+	)
 
-	for cause := e.cause; cause != nil; {
-		var xer *xError
-		if errors.As(cause, &xer) {
-			if xer.error != nil {
-				builder.WriteString(": ")
-				builder.WriteString(xer.error.Error())
+	write := func(message string) {
+		if message == "" {
+			return
+		}
+
+		if written {
+			builder.WriteString(Separator)
+		}
+
+		builder.WriteString(render(message))
+
+		written = true
+	}
+
+	if e.error != nil {
+		write(e.error.Error())
+	}
+
+	for cause := e.because(); cause != nil; {
+		xer, ok := step(cause)
+		if !ok {
+			if text, isBlock := cause.(block); isBlock && text != "" {
+				// the block keeps its newlines and starts on its own line
+				if written {
+					builder.WriteString(strings.TrimRight(Separator, " ") + "\n")
+				}
+
+				builder.WriteString(truncate(string(text)))
+
+				break
 			}
 
-			cause = xer.cause
-		} else {
-			builder.WriteString(": ")
-			builder.WriteString(cause.Error())
+			write(cause.Error())
 
 			break
 		}
+
+		if xer.error != nil {
+			write(xer.error.Error())
+		}
+
+		cause = xer.because()
 	}
 
 	return builder.String()
 }
 
+// because returns the cause of the link. The text of Reason is boxed into the Error only here,
+// so creating the error takes a single allocation.
+func (e *xError) because() error {
+	if e.cause == nil && e.text != "" {
+		return e.text
+	}
+
+	return e.cause
+}
+
+// reason returns the cause and the inline text of the link created by Reason. The empty text can't be
+// told apart from no cause when inline, so it stays the cause: boxing the empty string doesn't allocate.
+func reason(text string) (error, Error) {
+	if text == "" {
+		return Error(""), ""
+	}
+
+	return nil, Error(text)
+}
+
+// asXError finds the first xError in the error tree. A direct *xError is taken without errors.As,
+// which is only the fallback for foreign wrappers around ex errors.
+func asXError(err error) (*xError, bool) {
+	if xer, ok := err.(*xError); ok {
+		return xer, true
+	}
+
+	var xer *xError
+	if errors.As(err, &xer) {
+		return xer, true
+	}
+
+	return nil, false
+}
+
 // Unwrap returns the primary error, allowing compatibility with errors.Is and errors.As.
-// Note: It does not unwrap the cause. To access the cause, see the Is method or Expose function.
+// Note: It does not unwrap the cause. To access the cause, see the Is and As methods or Expose function.
 func (e *xError) Unwrap() error {
 	return e.error
 }
 
+// SetMaxMatchDepth limits the number of chain links the Is and As methods walk through (10000 by default),
+// so matching an adversarially deep chain stays bounded: links past the limit never match.
+// A not positive limit turns the limit off.
+func SetMaxMatchDepth(n int) {
+	if n <= 0 {
+		matchDepth.Store(-1)
+
+		return
+	}
+
+	matchDepth.Store(int64(n))
+}
+
 // Is checks if the target error matches the primary error or any error in the cause chain.
-// This makes xError fully compatible with errors.Is.
+// This makes xError fully compatible with errors.Is. The chain is walked iteratively
+// up to the limit set with SetMaxMatchDepth.
 func (e *xError) Is(target error) bool {
-	if errors.Is(e.error, target) {
-		return true
+	limit := maxMatchDepth()
+
+	var cause error = e
+
+	for depth := int64(0); cause != nil && depth < limit; depth++ {
+		xer, ok := cause.(*xError)
+		if !ok {
+			return errors.Is(cause, target)
+		}
+
+		if errors.Is(xer.error, target) {
+			return true
+		}
+
+		if xer.cause == nil && xer.text != "" {
+			// the inline text is matched as is, so matching doesn't box it
+			return xer.text == target || xer.text.Is(target)
+		}
+
+		cause = xer.cause
 	}
 
-	if e.cause != nil {
-		return errors.Is(e.cause, target)
+	return false
+}
+
+// As finds the first error matching the target in the primary error or, after it, in the cause chain.
+// This makes the causes reachable with errors.As the same way errors.Is reaches them,
+// e.g. a runtime.Error recovered from a panic. The chain is walked like the Is method does.
+func (e *xError) As(target any) bool {
+	limit := maxMatchDepth()
+
+	var cause error = e
+
+	for depth := int64(0); cause != nil && depth < limit; depth++ {
+		xer, ok := cause.(*xError)
+		if !ok {
+			return errors.As(cause, target)
+		}
+
+		if xer.error != nil && errors.As(xer.error, target) {
+			return true
+		}
+
+		cause = xer.because()
 	}
 
 	return false
 }
+
+// maxMatchDepth returns the limit set with SetMaxMatchDepth.
+func maxMatchDepth() int64 {
+	switch limit := matchDepth.Load(); {
+	case limit == 0:
+		return defaultMatchDepth
+	case limit < 0:
+		return math.MaxInt64
+	default:
+		return limit
+	}
+}
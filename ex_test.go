@@ -2,6 +2,12 @@ package ex_test
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -118,129 +124,1144 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestReIdentify(t *testing.T) {
+	t.Parallel()
+
+	const (
+		dbErr       = ex.Error("database error")
+		queryErr    = ex.Error("query failed")
+		publicErr   = ex.Error("service unavailable")
+		internalErr = ex.Error("internal")
+	)
+
+	ioErr := errors.New("connection reset")
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = ex.WithField(queryErr.Because(ioErr), "table", "users")
+			err   = ex.WithRequest(ex.WithField(dbErr.Because(inner), "user", 42), "GET", "/users/42", "req-1")
+			got   = ex.ReIdentify(err, publicErr)
+		)
+
+		method, path, id, ok := ex.RequestInfo(got)
+		identity, _ := ex.Expose(got)
+
+		require.Equal(t, publicErr, identity)
+		require.EqualError(t, got, "service unavailable: query failed: connection reset")
+		require.ErrorIs(t, got, queryErr)
+		require.ErrorIs(t, got, ioErr)
+		require.NotErrorIs(t, got, dbErr)
+		require.Equal(t, map[string]any{"user": 42, "table": "users"}, ex.Fields(got))
+		require.True(t, ok)
+		require.Equal(t, []string{"GET", "/users/42", "req-1"}, []string{method, path, id})
+		require.EqualError(t, err, "database error: query failed: connection reset")
+	})
+
+	t.Run("reason", func(t *testing.T) {
+		t.Parallel()
+
+		got := ex.ReIdentify(internalErr.Reason("timeout"), publicErr)
+
+		require.EqualError(t, got, "service unavailable: timeout")
+		require.True(t, ex.IsReason(got))
+	})
+
+	t.Run("bare identity", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, publicErr, ex.ReIdentify(internalErr, publicErr))
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		got := ex.ReIdentify(ioErr, publicErr)
+
+		require.EqualError(t, got, "service unavailable: connection reset")
+		require.ErrorIs(t, got, ioErr)
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.ReIdentify(nil, publicErr))
+	})
+}
+
+func TestAddCause(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiErr  = ex.Error("api error")
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	var (
+		extra   = errors.New("shard 3 unavailable")
+		timeout = errors.New("timeout")
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{
+			name: "standard leaf",
+			args: args{err: apiErr.Because(userErr.Because(dbErr.Because(timeout)))},
+			want: "api error: user not found: database error: timeout: shard 3 unavailable",
+		},
+		{
+			name: "reason leaf",
+			args: args{err: userErr.Because(dbErr.Reason("timeout"))},
+			want: "user not found: database error: timeout: shard 3 unavailable",
+		},
+		{
+			name: "no cause",
+			args: args{err: ex.Conv(dbErr)},
+			want: "database error: shard 3 unavailable",
+		},
+		{
+			name: "sentinel",
+			args: args{err: dbErr},
+			want: "database error: shard 3 unavailable",
+		},
+		{
+			name: "standard error",
+			args: args{err: timeout},
+			want: "timeout: shard 3 unavailable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.AddCause(test.args.err, extra)
+
+			require.EqualError(t, got, test.want)
+			require.Equal(t, extra.Error(), ex.CauseString(got))
+			require.ErrorIs(t, got, extra)
+
+			for _, link := range ex.ExposeAll(test.args.err) {
+				require.ErrorIs(t, got, link.Identity)
+			}
+		})
+	}
+
+	t.Run("keeps metadata", func(t *testing.T) {
+		t.Parallel()
+
+		got := ex.AddCause(ex.WithAttempts(userErr.Because(dbErr.Reason("timeout")), 3), extra)
+
+		attempts, ok := ex.Attempts(got)
+
+		require.True(t, ok)
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			err    = dbErr.Reason("timeout")
+			frozen = ex.Freeze(err)
+		)
+
+		require.Same(t, err, ex.AddCause(err, nil))
+		require.Same(t, frozen, ex.AddCause(frozen, extra))
+		require.NoError(t, ex.AddCause(nil, extra))
+		require.EqualError(t, err, "database error: timeout")
+	})
+}
+
+func TestIsReason(t *testing.T) {
+	t.Parallel()
+
+	const (
+		baseErr = ex.Error("base error")
+		text    = "a specific reason"
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want bool
+	}{
+		{name: "error reason", args: args{err: baseErr.Reason(text)}, want: true},
+		{name: "xerror reason", args: args{err: ex.Conv(baseErr).Reason(text)}, want: true},
+		{name: "converted reason", args: args{err: ex.Conv(baseErr.Reason(text))}, want: true},
+		{name: "error because", args: args{err: baseErr.Because(ex.Error(text))}, want: false},
+		{name: "xerror because", args: args{err: ex.Conv(baseErr.Reason(text)).Because(ex.Error(text))}, want: false},
+		{name: "reason deeper", args: args{err: baseErr.Because(baseErr.Reason(text))}, want: false},
+		{name: "standard error", args: args{err: errors.New(text)}, want: false},
+		{name: "nothing passed", args: args{err: nil}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.IsReason(test.args.err)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiErr  = ex.Error("api error")
+		userErr = ex.Error("user not found")
+	)
+
+	t.Run("wrapping is a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			err    = ex.Freeze(apiErr.Because(userErr.Reason("no rows")))
+			frozen = ex.Conv(err)
+		)
+
+		require.Same(t, frozen, frozen.Because(errors.New("noise")))
+		require.Same(t, frozen, frozen.Reason("noise"))
+		require.EqualError(t, err, "api error: user not found: no rows")
+		require.ErrorIs(t, err, apiErr)
+		require.ErrorIs(t, err, userErr)
+		require.True(t, ex.IsFrozen(err))
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			std = errors.New("standard")
+			err = ex.Freeze(std)
+		)
+
+		require.Equal(t, err, ex.Conv(err).Reason("noise"))
+		require.EqualError(t, err, "standard")
+		require.ErrorIs(t, err, std)
+		require.True(t, ex.IsFrozen(err))
+	})
+
+	t.Run("original untouched", func(t *testing.T) {
+		t.Parallel()
+
+		err := apiErr.Reason("timeout")
+
+		_ = ex.Freeze(err)
+
+		require.False(t, ex.IsFrozen(err))
+		require.EqualError(t, ex.Conv(err).Reason("retry"), "api error: retry")
+	})
+
+	t.Run("wrapped by another identity", func(t *testing.T) {
+		t.Parallel()
+
+		err := userErr.Because(ex.Freeze(apiErr))
+
+		require.False(t, ex.IsFrozen(err))
+		require.EqualError(t, err, "user not found: api error")
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.Freeze(nil))
+		require.False(t, ex.IsFrozen(nil))
+		require.False(t, ex.IsFrozen(apiErr))
+	})
+}
+
 func TestPanic(t *testing.T) {
 	t.Parallel()
 
-	t.Run("no panic", func(t *testing.T) {
-		t.Parallel()
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		require.NotPanics(t, func() {
+			ex.Panic(nil)
+		})
+	})
+
+	t.Run("with panic", func(t *testing.T) {
+		t.Parallel()
+
+		const text = "critical: super fail"
+
+		err := errors.New("super fail")
+
+		require.PanicsWithError(t, text, func() {
+			ex.Panic(err)
+		})
+	})
+}
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no skip", func(t *testing.T) {
+		t.Parallel()
+
+		ex.Skip(nil)
+	})
+
+	t.Run("with skip", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.New("super fail")
+
+		ex.Skip(err) // nothing happens here - it's just a mark
+	})
+}
+
+func TestHandle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no error", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+
+		require.False(t, ex.Handle(nil, func(error) { calls++ }))
+		require.Zero(t, calls)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			err   = errors.New("super fail")
+			calls []error
+		)
+
+		require.True(t, ex.Handle(err, func(err error) { calls = append(calls, err) }))
+		require.Equal(t, []error{err}, calls)
+		require.True(t, ex.Handle(err, nil))
+	})
+}
+
+type closer struct {
+	err    error
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+
+	return c.err
+}
+
+func TestDeferClose(t *testing.T) {
+	t.Parallel()
+
+	const readErr = ex.Error("read failed")
+
+	var (
+		closeErr = errors.New("disk full")
+		use      = func(resource io.Closer, fail error) (err error) {
+			defer ex.DeferClose(&err, resource)
+
+			return fail
+		}
+	)
+
+	t.Run("no prior error", func(t *testing.T) {
+		t.Parallel()
+
+		resource := &closer{err: closeErr, closed: false}
+		err := use(resource, nil)
+
+		require.True(t, resource.closed)
+		require.EqualError(t, err, "close failed: disk full")
+		require.ErrorIs(t, err, ex.ErrCloseFailed)
+		require.ErrorIs(t, err, closeErr)
+	})
+
+	t.Run("prior error present", func(t *testing.T) {
+		t.Parallel()
+
+		resource := &closer{err: closeErr, closed: false}
+		err := use(resource, readErr.Reason("timeout"))
+
+		require.True(t, resource.closed)
+		require.EqualError(t, err, "read failed: timeout: close failed: disk full")
+		require.ErrorIs(t, err, readErr)
+		require.ErrorIs(t, err, ex.ErrCloseFailed)
+		require.ErrorIs(t, err, closeErr)
+
+		identity, _ := ex.Expose(err)
+
+		require.Equal(t, readErr, identity)
+	})
+
+	t.Run("close succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			resource = &closer{err: nil, closed: false}
+			prior    = readErr.Reason("timeout")
+		)
+
+		require.NoError(t, use(resource, nil))
+		require.True(t, resource.closed)
+		require.Same(t, prior, use(resource, prior))
+	})
+}
+
+func TestDefer(t *testing.T) {
+	t.Parallel()
+
+	const (
+		cleanupErr = ex.Error("cleanup failed")
+		readErr    = ex.Error("read failed")
+	)
+
+	var (
+		tempErr = errors.New("temp dir busy")
+		use     = func(cleanup func() error, fail error) (err error) {
+			defer ex.Defer(&err, cleanupErr, cleanup)
+
+			return fail
+		}
+		failing = func() error { return tempErr }
+		passing = func() error { return nil }
+	)
+
+	type args struct {
+		cleanup func() error
+		fail    error
+	}
+
+	type want struct {
+		identity error
+		message  string
+		is       []error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "no prior error",
+			args: args{cleanup: failing, fail: nil},
+			want: want{identity: cleanupErr, message: "cleanup failed: temp dir busy", is: []error{tempErr}},
+		},
+		{
+			name: "prior error present",
+			args: args{cleanup: failing, fail: readErr.Reason("timeout")},
+			want: want{
+				identity: readErr,
+				message:  "read failed: timeout: cleanup failed: temp dir busy",
+				is:       []error{cleanupErr, tempErr},
+			},
+		},
+		{
+			name: "cleanup succeeds",
+			args: args{cleanup: passing, fail: readErr.Reason("timeout")},
+			want: want{identity: readErr, message: "read failed: timeout", is: nil},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := use(test.args.cleanup, test.args.fail)
+			identity, _ := ex.Expose(got)
+
+			require.EqualError(t, got, test.want.message)
+			require.Equal(t, test.want.identity, identity)
+
+			for _, target := range test.want.is {
+				require.ErrorIs(t, got, target)
+			}
+		})
+	}
+
+	t.Run("nothing failed", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, use(passing, nil))
+	})
+}
+
+func TestExpose(t *testing.T) {
+	t.Parallel()
+
+	t.Run("xerror passed", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			baseErr  = ex.Error("base text")
+			constErr = ex.Error("some error")
+		)
+
+		var (
+			err        = ex.Conv(baseErr).Because(constErr)
+			got, cause = ex.Expose(err)
+		)
+
+		require.ErrorIs(t, got, baseErr)
+		require.ErrorIs(t, cause, constErr)
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		got, cause := ex.Expose(nil)
+
+		require.NoError(t, got)
+		require.NoError(t, cause)
+	})
+
+	t.Run("standard passed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			err        = errors.New("not an ex")
+			got, cause = ex.Expose(err)
+		)
+
+		require.ErrorIs(t, got, err)
+		require.NoError(t, cause)
+	})
+}
+
+func TestPromote(t *testing.T) {
+	t.Parallel()
+
+	t.Run("two-level chain", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			uselessErr = ex.Error("useless")
+			dbErr      = ex.Error("database error")
+		)
+
+		var (
+			causeErr = errors.New("connection reset")
+			err      = uselessErr.Because(dbErr.Because(causeErr))
+			got      = ex.Promote(err)
+		)
+
+		require.EqualError(t, got, "database error: connection reset")
+		require.ErrorIs(t, got, dbErr)
+		require.ErrorIs(t, got, causeErr)
+		require.NotErrorIs(t, got, uselessErr)
+	})
+
+	t.Run("no cause wrapper", func(t *testing.T) {
+		t.Parallel()
+
+		const baseErr = ex.Error("base error")
+
+		var (
+			err = ex.Conv(baseErr)
+			got = ex.Promote(err)
+		)
+
+		require.Equal(t, baseErr, got)
+		require.NotEqual(t, err, got)
+	})
+
+	t.Run("fmt wrapper", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			aErr = ex.Error("a")
+			bErr = ex.Error("b")
+		)
+
+		got := ex.Promote(fmt.Errorf("ctx: %w", aErr.Because(bErr)))
+
+		require.EqualError(t, got, "a: b")
+		require.ErrorIs(t, got, aErr)
+		require.ErrorIs(t, got, bErr)
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		stdErr := errors.New("standard error")
+
+		require.Same(t, stdErr, ex.Promote(stdErr))
+		require.NoError(t, ex.Promote(nil))
+	})
+}
+
+func TestUnexpected(t *testing.T) {
+	t.Parallel()
+
+	var (
+		causeErr   = errors.New("unexpected failure")
+		err        = ex.Unexpected(causeErr)
+		got, cause = ex.Expose(err)
+	)
+
+	require.ErrorIs(t, got, ex.ErrUnexpected)
+	require.ErrorIs(t, cause, causeErr)
+	require.EqualError(t, err, "unexpected: unexpected failure")
+	require.NoError(t, ex.Unexpected(nil))
+}
+
+func TestSkipIf(t *testing.T) {
+	t.Parallel()
+
+	const readErr = ex.Error("read failed")
+
+	var (
+		eof      = readErr.Because(io.EOF)
+		closed   = readErr.Because(io.ErrClosedPipe)
+		standard = errors.New("standard")
+	)
+
+	type args struct {
+		err     error
+		targets []error
+	}
+
+	tests := []struct {
+		want error
+		name string
+		args args
+	}{
+		{name: "expected", args: args{err: eof, targets: []error{io.ErrUnexpectedEOF, io.EOF}}, want: nil},
+		{name: "expected identity", args: args{err: closed, targets: []error{readErr}}, want: nil},
+		{name: "unexpected", args: args{err: closed, targets: []error{io.EOF}}, want: closed},
+		{name: "standard", args: args{err: standard, targets: []error{io.EOF}}, want: standard},
+		{name: "no targets", args: args{err: eof, targets: nil}, want: eof},
+		{name: "nothing passed", args: args{err: nil, targets: []error{io.EOF}}, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.SkipIf(test.args.err, test.args.targets...)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestOnlyIf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		notFoundErr = ex.Error("not found")
+		conflictErr = ex.Error("conflict")
+	)
+
+	var (
+		ioErr   = errors.New("connection reset")
+		known   = conflictErr.Because(ioErr)
+		unknown = ex.Error("database error").Because(ioErr)
+	)
+
+	type args struct {
+		err     error
+		targets []error
+	}
+
+	tests := []struct {
+		want error
+		name string
+		args args
+	}{
+		{name: "known identity", args: args{err: known, targets: []error{notFoundErr, conflictErr}}, want: known},
+		{name: "known cause", args: args{err: unknown, targets: []error{ioErr}}, want: unknown},
+		{name: "unknown", args: args{err: unknown, targets: []error{notFoundErr}}, want: ex.Unexpected(unknown)},
+		{name: "no targets", args: args{err: known, targets: nil}, want: ex.Unexpected(known)},
+		{name: "nothing passed", args: args{err: nil, targets: []error{notFoundErr}}, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.OnlyIf(test.args.err, test.args.targets...)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestMustNotContain(t *testing.T) {
+	t.Parallel()
+
+	const (
+		internalErr = ex.Error("internal: database error")
+		secretErr   = ex.Error("internal: secret expired")
+		publicErr   = ex.Error("user not found")
+	)
+
+	type args struct {
+		err       error
+		forbidden []ex.Error
+	}
+
+	tests := []struct {
+		want error
+		name string
+		args args
+	}{
+		{
+			name: "forbidden identity",
+			args: args{
+				err:       publicErr.Because(internalErr.Reason("timeout")),
+				forbidden: []ex.Error{secretErr, internalErr},
+			},
+			want: ex.ErrForbiddenIdentity.Reason(`"internal: database error"`),
+		},
+		{
+			name: "forbidden root",
+			args: args{err: secretErr, forbidden: []ex.Error{secretErr}},
+			want: ex.ErrForbiddenIdentity.Reason(`"internal: secret expired"`),
+		},
+		{
+			name: "clean chain",
+			args: args{err: publicErr.Reason("no rows"), forbidden: []ex.Error{internalErr, secretErr}},
+			want: nil,
+		},
+		{
+			name: "nothing forbidden",
+			args: args{err: internalErr, forbidden: nil},
+			want: nil,
+		},
+		{
+			name: "nothing passed",
+			args: args{err: nil, forbidden: []ex.Error{internalErr}},
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.MustNotContain(test.args.err, test.args.forbidden...)
+
+			require.Equal(t, test.want, got)
+
+			for _, identity := range test.args.forbidden {
+				require.NotErrorIs(t, got, identity)
+			}
+		})
+	}
+}
+
+func TestCritical(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		require.NotPanics(t, func() {
+			got := ex.Critical(42, nil)
+			want := 42
+
+			require.Equal(t, want, got)
+		})
+	})
+
+	t.Run("with panic", func(t *testing.T) {
+		t.Parallel()
+
+		const text = "critical: critical failure"
+
+		causeErr := errors.New("critical failure")
+
+		require.PanicsWithError(t, text, func() {
+			_ = ex.Critical(0, causeErr)
+		})
+	})
+}
+
+func TestFromPanic(t *testing.T) {
+	t.Parallel()
+
+	const panicErr = ex.Error("panic error")
+
+	recovered := func(fn func()) (err error) {
+		defer func() { err = ex.FromPanic(recover()) }()
+
+		fn()
+
+		return nil
+	}
+
+	type args struct {
+		fn func()
+	}
+
+	type want struct {
+		root    error
+		message string
+	}
+
+	tests := []struct {
+		args args
+		want want
+		name string
+	}{
+		{
+			name: "critical",
+			args: args{fn: func() { ex.Panic(panicErr.Reason("broken")) }},
+			want: want{root: ex.ErrCritical, message: "critical: panic error: broken"},
+		},
+		{
+			name: "error",
+			args: args{fn: func() { panic(panicErr) }},
+			want: want{root: ex.ErrUnexpected, message: "unexpected: panic error"},
+		},
+		{
+			name: "string",
+			args: args{fn: func() { panic("boom") }},
+			want: want{root: ex.ErrUnexpected, message: "unexpected: boom"},
+		},
+		{
+			name: "value",
+			args: args{fn: func() { panic(42) }},
+			want: want{root: ex.ErrUnexpected, message: "unexpected: 42 (int)"},
+		},
+		{
+			name: "runtime error",
+			args: args{fn: func() {
+				var m map[string]int
+
+				m["key"]++
+			}},
+			want: want{root: ex.ErrUnexpected, message: "unexpected: assignment to entry in nil map"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := recovered(test.args.fn)
+
+			require.EqualError(t, err, test.want.message)
+
+			root, _ := ex.Expose(err)
+
+			require.Equal(t, test.want.root, root)
+		})
+	}
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, recovered(func() {}))
+	})
+}
+
+func TestFromRecoveredPanic(t *testing.T) {
+	t.Parallel()
+
+	const handlerErr = ex.Error("handler failed")
+
+	recovered := func(fn func()) (err error) {
+		defer func() { err = ex.FromPanic(recover()) }()
+
+		fn()
+
+		return nil
+	}
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want bool
+	}{
+		{name: "value", args: args{err: recovered(func() { panic("boom") })}, want: true},
+		{name: "critical", args: args{err: recovered(func() { ex.Panic(handlerErr) })}, want: true},
+		{name: "wrapped", args: args{err: handlerErr.Because(recovered(func() { panic(42) }))}, want: true},
+		{name: "recovered value", args: args{err: ex.RecoverValue(errors.New("boom"))}, want: true},
+		{name: "unexpected", args: args{err: ex.Unexpected(errors.New("boom"))}, want: false},
+		{name: "ordinary", args: args{err: handlerErr.Reason("boom")}, want: false},
+		{name: "standard error", args: args{err: errors.New("boom")}, want: false},
+		{name: "nothing passed", args: args{err: nil}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, ex.FromRecoveredPanic(test.args.err))
+		})
+	}
+}
+
+type panicValue struct {
+	Code int
+}
+
+func TestRecoverValue(t *testing.T) {
+	t.Parallel()
+
+	const panicErr = ex.Error("panic error")
 
-		require.NotPanics(t, func() {
-			ex.Panic(nil)
+	var (
+		stdErr = errors.New("standard")
+		chain  = panicErr.Reason("broken")
+	)
+
+	type args struct {
+		v any
+	}
+
+	tests := []struct {
+		args args
+		want error
+		name string
+	}{
+		{name: "nil", args: args{v: nil}, want: nil},
+		{name: "standard error", args: args{v: stdErr}, want: ex.ErrCritical.Because(stdErr)},
+		{name: "ex error", args: args{v: chain}, want: ex.ErrCritical.Because(chain)},
+		{name: "string", args: args{v: "boom"}, want: ex.ErrCritical.Reason("boom")},
+		{name: "int", args: args{v: 42}, want: ex.ErrCritical.Reason("42 (int)")},
+		{name: "struct", args: args{v: panicValue{Code: 7}}, want: ex.ErrCritical.Reason("{7} (ex_test.panicValue)")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.RecoverValue(test.args.v)
+
+			require.Equal(t, ex.ExposeAll(test.want), ex.ExposeAll(got))
+			require.Equal(t, ex.IsReason(test.want), ex.IsReason(got))
+			require.Equal(t, test.want != nil, ex.FromRecoveredPanic(got))
 		})
+	}
+
+	t.Run("critical panic", func(t *testing.T) {
+		t.Parallel()
+
+		var err error
+
+		func() {
+			defer func() { err = ex.RecoverValue(recover()) }()
+
+			ex.Panic(chain)
+		}()
+
+		require.EqualError(t, err, "critical: panic error: broken")
 	})
 
-	t.Run("with panic", func(t *testing.T) {
+	t.Run("runtime error", func(t *testing.T) {
 		t.Parallel()
 
-		const text = "critical: super fail"
+		var err error
 
-		err := errors.New("super fail")
+		func() {
+			defer func() { err = ex.RecoverValue(recover()) }()
 
-		require.PanicsWithError(t, text, func() {
-			ex.Panic(err)
-		})
+			var values []int
+
+			_ = values[len(values)]
+		}()
+
+		var runtimeErr runtime.Error
+
+		require.ErrorAs(t, err, &runtimeErr)
+		require.ErrorIs(t, err, ex.ErrCritical)
+		require.EqualError(t, err, "critical: "+runtimeErr.Error())
 	})
 }
 
-func TestSkip(t *testing.T) {
+func TestXErrorAs(t *testing.T) {
 	t.Parallel()
 
-	t.Run("no skip", func(t *testing.T) {
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	pathErr := &fs.PathError{Op: "open", Path: "db.sqlite", Err: fs.ErrNotExist}
+
+	t.Run("cause", func(t *testing.T) {
 		t.Parallel()
 
-		ex.Skip(nil)
+		var target *fs.PathError
+
+		require.ErrorAs(t, userErr.Because(dbErr.Because(pathErr)), &target)
+		require.Same(t, pathErr, target)
 	})
 
-	t.Run("with skip", func(t *testing.T) {
+	t.Run("identity first", func(t *testing.T) {
 		t.Parallel()
 
-		err := errors.New("super fail")
+		var target ex.Error
 
-		ex.Skip(err) // nothing happens here - it's just a mark
+		require.ErrorAs(t, userErr.Because(dbErr.Reason("timeout")), &target)
+		require.Equal(t, userErr, target)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		var target *fs.PathError
+
+		require.False(t, errors.As(userErr.Reason("timeout"), &target))
 	})
 }
 
-func TestExpose(t *testing.T) {
+func TestMustWith(t *testing.T) {
 	t.Parallel()
 
-	t.Run("xerror passed", func(t *testing.T) {
-		t.Parallel()
+	const configErr = ex.Error("config error")
 
-		const (
-			baseErr  = ex.Error("base text")
-			constErr = ex.Error("some error")
-		)
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
 
-		var (
-			err        = ex.Conv(baseErr).Because(constErr)
-			got, cause = ex.Expose(err)
-		)
+		require.NotPanics(t, func() {
+			got := ex.MustWith(configErr, "config", nil)
 
-		require.ErrorIs(t, got, baseErr)
-		require.ErrorIs(t, cause, constErr)
+			require.Equal(t, "config", got)
+		})
 	})
 
-	t.Run("nothing passed", func(t *testing.T) {
+	t.Run("with panic", func(t *testing.T) {
 		t.Parallel()
 
-		got, cause := ex.Expose(nil)
+		causeErr := errors.New("file not found")
 
-		require.NoError(t, got)
-		require.NoError(t, cause)
+		defer func() {
+			err, ok := recover().(error)
+
+			require.True(t, ok)
+			require.EqualError(t, err, "critical: config error: file not found")
+			require.ErrorIs(t, err, ex.ErrCritical)
+			require.ErrorIs(t, err, configErr)
+			require.ErrorIs(t, err, causeErr)
+		}()
+
+		_ = ex.MustWith(configErr, "config", causeErr)
 	})
+}
 
-	t.Run("standard passed", func(t *testing.T) {
+func TestWrapAll(t *testing.T) {
+	t.Parallel()
+
+	const (
+		billingErr  = ex.Error("billing error")
+		upstreamErr = ex.Error("upstream error")
+		gatewayErr  = ex.Error("gateway error")
+	)
+
+	causeErr := errors.New("connection reset")
+
+	t.Run("identities", func(t *testing.T) {
 		t.Parallel()
 
-		var (
-			err        = errors.New("not an ex")
-			got, cause = ex.Expose(err)
-		)
+		err := ex.WrapAll(causeErr, billingErr, upstreamErr, gatewayErr)
 
-		require.ErrorIs(t, got, err)
-		require.NoError(t, cause)
+		for _, target := range []error{billingErr, upstreamErr, gatewayErr, causeErr} {
+			require.ErrorIs(t, err, target)
+		}
+
+		require.Equal(t, billingErr.Because(upstreamErr.Because(gatewayErr.Because(causeErr))), err)
+		require.Equal(t,
+			[]string{"billing error", "upstream error", "gateway error", "connection reset"}, ex.Flatten(err))
+
+		for depth, target := range []error{billingErr, upstreamErr, gatewayErr, causeErr} {
+			got, ok := ex.DepthOf(err, target)
+
+			require.True(t, ok)
+			require.Equal(t, depth, got)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		require.Same(t, causeErr, ex.WrapAll(causeErr))
+		require.NoError(t, ex.WrapAll(nil, billingErr))
 	})
 }
 
-func TestUnexpected(t *testing.T) {
+func TestChained(t *testing.T) {
 	t.Parallel()
 
-	var (
-		causeErr   = errors.New("unexpected failure")
-		err        = ex.Unexpected(causeErr)
-		got, cause = ex.Expose(err)
+	const (
+		userErr = ex.Error("user not found")
+		repoErr = ex.Error("repository error")
+		dbErr   = ex.Error("database error")
 	)
 
-	require.ErrorIs(t, got, ex.ErrUnexpected)
-	require.ErrorIs(t, cause, causeErr)
-	require.EqualError(t, err, "unexpected: unexpected failure")
-	require.NoError(t, ex.Unexpected(nil))
-}
+	ioErr := errors.New("connection reset")
 
-func TestCritical(t *testing.T) {
-	t.Parallel()
+	t.Run("equivalent to manual", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("no panic", func(t *testing.T) {
+		manual := userErr.Because(ex.Conv(repoErr).Because(ex.Conv(dbErr).Because(ioErr)))
+
+		for _, got := range []error{
+			ex.Chained(userErr, repoErr, dbErr, ioErr),
+			userErr.Chain(repoErr, dbErr, ioErr),
+			userErr.Chain(nil, repoErr, nil, dbErr, ioErr, nil),
+		} {
+			require.Equal(t, manual.Error(), got.Error())
+			require.Equal(t, ex.Flatten(manual), ex.Flatten(got))
+
+			for _, target := range []error{userErr, repoErr, dbErr, ioErr} {
+				require.ErrorIs(t, got, target)
+			}
+		}
+	})
+
+	t.Run("frozen link", func(t *testing.T) {
 		t.Parallel()
 
-		require.NotPanics(t, func() {
-			got := ex.Critical(42, nil)
-			want := 42
+		frozen := ex.Freeze(userErr.Reason("x"))
 
-			require.Equal(t, want, got)
-		})
+		got := ex.Chained(frozen, repoErr, ioErr)
+
+		require.EqualError(t, got, "user not found: repository error: connection reset")
+		require.ErrorIs(t, got, repoErr)
+		require.ErrorIs(t, got, ioErr)
+		require.False(t, ex.IsFrozen(got))
+
+		require.EqualError(t, frozen, "user not found: x")
+		require.True(t, ex.IsFrozen(frozen))
 	})
 
-	t.Run("with panic", func(t *testing.T) {
+	t.Run("single link", func(t *testing.T) {
 		t.Parallel()
 
-		const text = "critical: critical failure"
+		require.Equal(t, ioErr, ex.Chained(nil, ioErr, nil))
+		require.Equal(t, userErr, userErr.Chain())
+		require.Equal(t, userErr, userErr.Chain(nil, nil))
+	})
 
-		causeErr := errors.New("critical failure")
+	t.Run("all nil", func(t *testing.T) {
+		t.Parallel()
 
-		require.PanicsWithError(t, text, func() {
-			_ = ex.Critical(0, causeErr)
-		})
+		require.NoError(t, ex.Chained())
+		require.NoError(t, ex.Chained(nil, nil))
 	})
 }
 
@@ -292,6 +1313,26 @@ func TestError(t *testing.T) {
 
 		require.ErrorIs(t, got, constErr)
 		require.ErrorIs(t, cause, ex.Error(text))
+		require.ErrorIs(t, err, ex.Error(text))
+		require.EqualError(t, err, "base error: a specific reason")
+		require.Equal(t, []string{"base error", text}, ex.Flatten(err))
+	})
+
+	t.Run("empty Reason", func(t *testing.T) {
+		t.Parallel()
+
+		const constErr = ex.Error("base error")
+
+		var (
+			err        = constErr.Reason("")
+			_, cause   = ex.Expose(err)
+			_, cleared = ex.Expose(ex.Conv(constErr.Reason("x")).Because(nil))
+		)
+
+		require.Equal(t, ex.Error(""), cause)
+		require.Nil(t, cleared)
+		require.EqualError(t, err, "base error")
+		require.True(t, ex.IsReason(err))
 	})
 
 	t.Run("Error", func(t *testing.T) {
@@ -485,3 +1526,286 @@ func TestErrorChainWithMixedTypes(t *testing.T) {
 
 	require.EqualError(t, err, "ex error 2: standard error 2: ex error 1: standard error 1")
 }
+
+func TestErrorEmptyMessages(t *testing.T) {
+	t.Parallel()
+
+	const (
+		empty = ex.Error("")
+		dbErr = ex.Error("database error")
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{name: "empty identity", args: args{err: empty.Reason("timeout")}, want: "timeout"},
+		{name: "empty reason", args: args{err: dbErr.Reason("")}, want: "database error"},
+		{name: "fully empty", args: args{err: empty.Reason("")}, want: ""},
+		{
+			name: "empty middle",
+			args: args{err: dbErr.Because(empty.Because(errors.New("timeout")))},
+			want: "database error: timeout",
+		},
+		{name: "empty leaf", args: args{err: dbErr.Because(errors.New(""))}, want: "database error"},
+		{name: "empty chain", args: args{err: ex.Conv(empty).Because(empty.Because(empty))}, want: ""},
+		{name: "nil identity", args: args{err: ex.OnlyCause(empty.Reason("timeout"))}, want: "timeout"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, test.args.err, test.want)
+			require.Equal(t, test.want, strings.Join(ex.Flatten(test.args.err), ex.Separator))
+		})
+	}
+
+	t.Run("still matchable", func(t *testing.T) {
+		t.Parallel()
+
+		err := dbErr.Because(empty.Reason("timeout"))
+
+		require.ErrorIs(t, err, empty)
+		require.Equal(t, []string{"database error", "timeout"}, ex.Flatten(err))
+		require.Nil(t, ex.Flatten(empty.Reason("")))
+	})
+}
+
+func TestErrorReasonBlock(t *testing.T) {
+	t.Parallel()
+
+	const (
+		empty    = ex.Error("")
+		buildErr = ex.Error("build failed")
+		stepErr  = ex.Error("step failed")
+	)
+
+	stderr := "main.go:3:2: undefined: x\nmain.go:7:1: missing return\n"
+
+	type args struct {
+		err error
+	}
+
+	type want struct {
+		message string
+		dump    string
+	}
+
+	tests := []struct {
+		args args
+		want want
+		name string
+	}{
+		{
+			name: "block",
+			args: args{err: buildErr.ReasonBlock(stderr)},
+			want: want{
+				message: "build failed:\nmain.go:3:2: undefined: x\nmain.go:7:1: missing return",
+				dump:    "build failed\n    main.go:3:2: undefined: x\n    main.go:7:1: missing return",
+			},
+		},
+		{
+			name: "nested block",
+			args: args{err: stepErr.Because(buildErr.ReasonBlock("one\ntwo"))},
+			want: want{
+				message: "step failed: build failed:\none\ntwo",
+				dump:    "step failed\nbuild failed\n    one\n    two",
+			},
+		},
+		{
+			name: "empty identity",
+			args: args{err: empty.ReasonBlock("one\ntwo")},
+			want: want{message: "one\ntwo", dump: "    one\n    two"},
+		},
+		{
+			name: "empty block",
+			args: args{err: buildErr.ReasonBlock("\n")},
+			want: want{message: "build failed", dump: "build failed"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, test.args.err, test.want.message)
+			require.Equal(t, test.want.dump, fmt.Sprintf("%+v", test.args.err))
+			require.Equal(t, test.want.message, ex.TopN(test.args.err, 10))
+			require.Equal(t, test.want.message, ex.StringDedup(test.args.err))
+			require.Equal(t, test.want.message, ex.Localize(test.args.err, "en", nil))
+		})
+	}
+
+	t.Run("renderers", func(t *testing.T) {
+		t.Parallel()
+
+		err := stepErr.Because(stepErr.Because(buildErr.ReasonBlock("one\ntwo")))
+
+		require.Equal(t, []string{"step failed", "step failed", "build failed", "one\ntwo"}, ex.Flatten(err))
+		require.Equal(t, "step failed (x2): build failed:\none\ntwo", ex.StringDedup(err))
+		require.Equal(t, "step failed: step failed: …", ex.TopN(err, 2))
+	})
+
+	t.Run("reason", func(t *testing.T) {
+		t.Parallel()
+
+		require.True(t, ex.IsReason(buildErr.ReasonBlock(stderr)))
+		require.ErrorIs(t, stepErr.Because(buildErr.ReasonBlock(stderr)), buildErr)
+		require.EqualError(t, buildErr.Reason("one\ntwo"), `build failed: one\ntwo`)
+	})
+}
+
+//nolint:paralleltest // changes the process-wide match depth
+func TestSetMaxMatchDepth(t *testing.T) {
+	const (
+		nearErr = ex.Error("near root")
+		deepErr = ex.Error("deep")
+		linkErr = ex.Error("link")
+		depth   = 100_000
+	)
+
+	var err error = deepErr.Reason("bottom")
+	for range depth {
+		err = linkErr.Because(err)
+	}
+
+	err = ex.Error("root").Because(nearErr.Because(err))
+
+	defer ex.SetMaxMatchDepth(10_000)
+
+	t.Run("default", func(t *testing.T) {
+		require.ErrorIs(t, err, nearErr)
+		require.NotErrorIs(t, err, deepErr)
+
+		var identity ex.Error
+
+		require.ErrorAs(t, err, &identity)
+		require.Equal(t, ex.Error("root"), identity)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		ex.SetMaxMatchDepth(2)
+
+		require.ErrorIs(t, err, nearErr)
+		require.NotErrorIs(t, err, linkErr)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		ex.SetMaxMatchDepth(0)
+
+		require.ErrorIs(t, err, deepErr)
+		require.ErrorIs(t, err, ex.Error("bottom"))
+	})
+}
+
+func TestErrorForeignWrapper(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{
+			name: "direct links",
+			args: args{err: userErr.Because(dbErr.Because(errors.New("timeout")))},
+			want: "user not found: database error: timeout",
+		},
+		{
+			name: "wrapped ex link",
+			args: args{err: userErr.Because(fmt.Errorf("query: %w", dbErr.Reason("timeout")))},
+			want: "user not found: query: database error: timeout",
+		},
+		{
+			name: "wrapped standard error",
+			args: args{err: userErr.Because(fmt.Errorf("query: %w", errors.New("timeout")))},
+			want: "user not found: query: timeout",
+		},
+		{
+			name: "wrapped sentinel",
+			args: args{err: userErr.Because(fmt.Errorf("query: %w", dbErr))},
+			want: "user not found: query: database error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, test.args.err, test.want)
+			require.Equal(t, test.want, strings.Join(ex.Flatten(test.args.err), ex.Separator))
+		})
+	}
+}
+
+func BenchmarkExpose(b *testing.B) {
+	for _, depth := range []int{1, 15, 100} {
+		var err error = ex.Error("root")
+		for range depth {
+			err = ex.Conv(ex.Error("level")).Because(err)
+		}
+
+		b.Run(strconv.Itoa(depth), func(b *testing.B) {
+			for b.Loop() {
+				_, _ = ex.Expose(err)
+			}
+		})
+	}
+}
+
+func BenchmarkError(b *testing.B) {
+	for _, depth := range []int{3, 10, 50} {
+		var err error = ex.Error("root")
+		for range depth - 1 {
+			err = ex.Error("level").Because(err)
+		}
+
+		b.Run(strconv.Itoa(depth), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				_ = err.Error()
+			}
+		})
+	}
+}
+
+func BenchmarkReason(b *testing.B) {
+	const dbErr = ex.Error("database error")
+
+	text := strconv.Itoa(b.N) + " rows"
+
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+
+		var err error
+		for b.Loop() {
+			err = dbErr.Reason(text)
+		}
+
+		_ = err
+	})
+
+	b.Run("message", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_ = dbErr.Reason(text).Error()
+		}
+	})
+}
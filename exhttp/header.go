@@ -0,0 +1,70 @@
+package exhttp
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// The headers carrying the error between services, see ToHeader and FromHeader.
+const (
+	HeaderSlug      = "X-Error-Slug"
+	HeaderCode      = "X-Error-Code"
+	HeaderRetryable = "X-Error-Retryable"
+)
+
+// ToHeader writes the identity and code of the error to the response headers, so gateways can make
+// routing and retry decisions without parsing the body: X-Error-Slug holds the slug of the outermost
+// identity (see ex.LabelKey), X-Error-Code holds the code (see ex.CodeOf), if any, and X-Error-Retryable
+// holds whether the error is retryable (see ex.Retryable). The slug only has [a-z0-9_], so the values are safe
+// as is. If the error is nil, the headers are removed.
+func ToHeader(h http.Header, err error) {
+	h.Del(HeaderSlug)
+	h.Del(HeaderCode)
+	h.Del(HeaderRetryable)
+
+	if err == nil {
+		return
+	}
+
+	h.Set(HeaderSlug, ex.LabelKey(err))
+	h.Set(HeaderRetryable, strconv.FormatBool(ex.Retryable(err)))
+
+	if code, ok := ex.CodeOf(err); ok {
+		h.Set(HeaderCode, strconv.Itoa(code))
+	}
+}
+
+// FromHeader reconstructs the error written by ToHeader: the identity registered with the slug
+// (see ex.Registry) or, for the unknown slugs, the slug itself, with the header values as fields.
+// The code is attached with ex.WithCode and the retryable mark with ex.WithRetryable as well.
+// It reports false if there is no X-Error-Slug header.
+func FromHeader(h http.Header) (error, bool) {
+	slug := h.Get(HeaderSlug)
+	if slug == "" {
+		return nil, false
+	}
+
+	identity := ex.Error(slug)
+
+	for _, info := range ex.Registry() {
+		if ex.LabelKey(info.Identity) == slug {
+			identity = info.Identity
+
+			break
+		}
+	}
+
+	result := ex.WithField(identity, "slug", slug)
+
+	if code, err := strconv.Atoi(h.Get(HeaderCode)); err == nil {
+		result = ex.WithCode(ex.WithField(result, "code", code), code)
+	}
+
+	if retryable, err := strconv.ParseBool(h.Get(HeaderRetryable)); err == nil {
+		result = ex.WithRetryable(ex.WithField(result, "retryable", retryable), retryable)
+	}
+
+	return result, true
+}
@@ -0,0 +1,126 @@
+package exhttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/exhttp"
+)
+
+func TestHeader(t *testing.T) {
+	t.Parallel()
+
+	const (
+		limitErr  = ex.Error("exhttp test: Rate Limited!")
+		unseenErr = ex.Error("exhttp test: café / menu")
+	)
+
+	ex.RegisterCode(limitErr, http.StatusTooManyRequests)
+	ex.RegisterRetryable(limitErr)
+
+	roundTrip := func(t *testing.T, err error) http.Header {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			exhttp.ToHeader(w.Header(), err)
+			w.WriteHeader(http.StatusTeapot)
+		}))
+		t.Cleanup(server.Close)
+
+		req, rErr := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+		require.NoError(t, rErr)
+
+		resp, rErr := http.DefaultClient.Do(req)
+		require.NoError(t, rErr)
+
+		require.NoError(t, resp.Body.Close())
+
+		return resp.Header
+	}
+
+	t.Run("registered identity", func(t *testing.T) {
+		t.Parallel()
+
+		header := roundTrip(t, limitErr.Reason("100 rps"))
+
+		got, ok := exhttp.FromHeader(header)
+		code, hasCode := ex.CodeOf(got)
+
+		require.True(t, ok)
+		require.Equal(t, "exhttp_test_rate_limited", header.Get(exhttp.HeaderSlug))
+		require.Equal(t, "429", header.Get(exhttp.HeaderCode))
+		require.Equal(t, "true", header.Get(exhttp.HeaderRetryable))
+		require.ErrorIs(t, got, limitErr)
+		require.True(t, hasCode)
+		require.Equal(t, http.StatusTooManyRequests, code)
+		require.True(t, ex.Retryable(got))
+		require.Equal(t, map[string]any{
+			"slug":      "exhttp_test_rate_limited",
+			"code":      429,
+			"retryable": true,
+		}, ex.Fields(got))
+	})
+
+	t.Run("unknown identity", func(t *testing.T) {
+		t.Parallel()
+
+		header := roundTrip(t, unseenErr.Because(errors.New("closed")))
+
+		got, ok := exhttp.FromHeader(header)
+
+		require.True(t, ok)
+		require.Empty(t, header.Get(exhttp.HeaderCode))
+		require.Equal(t, "false", header.Get(exhttp.HeaderRetryable))
+		require.EqualError(t, got, "exhttp_test_caf_menu")
+		require.False(t, ex.Retryable(got))
+		require.Equal(t, map[string]any{"slug": "exhttp_test_caf_menu", "retryable": false}, ex.Fields(got))
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		t.Parallel()
+
+		header := http.Header{}
+		header.Set(exhttp.HeaderSlug, "rate_limited")
+		header.Set(exhttp.HeaderCode, "not a number")
+		header.Set(exhttp.HeaderRetryable, "maybe")
+
+		got, ok := exhttp.FromHeader(header)
+		_, hasCode := ex.CodeOf(got)
+
+		require.True(t, ok)
+		require.EqualError(t, got, "rate_limited")
+		require.False(t, hasCode)
+		require.False(t, ex.Retryable(got))
+		require.Equal(t, map[string]any{"slug": "rate_limited"}, ex.Fields(got))
+	})
+
+	t.Run("absent headers", func(t *testing.T) {
+		t.Parallel()
+
+		header := roundTrip(t, nil)
+
+		got, ok := exhttp.FromHeader(header)
+
+		require.False(t, ok)
+		require.NoError(t, got)
+		require.Empty(t, header.Values(exhttp.HeaderSlug))
+		require.Empty(t, header.Values(exhttp.HeaderRetryable))
+	})
+
+	t.Run("previous headers", func(t *testing.T) {
+		t.Parallel()
+
+		header := http.Header{}
+		exhttp.ToHeader(header, limitErr)
+		exhttp.ToHeader(header, unseenErr)
+
+		require.Equal(t, "exhttp_test_caf_menu", header.Get(exhttp.HeaderSlug))
+		require.Empty(t, header.Get(exhttp.HeaderCode))
+		require.Equal(t, "false", header.Get(exhttp.HeaderRetryable))
+	})
+}
@@ -0,0 +1,25 @@
+package exhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// Manifest returns a handler serving ex.Manifest as JSON, e.g. mounted on a debug endpoint
+// so client teams can fetch the errors the service can return. The manifest is built on every request,
+// so it reflects the registrations made at runtime.
+func Manifest() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		data, err := json.Marshal(ex.Manifest())
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
@@ -0,0 +1,43 @@
+package exhttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/exhttp"
+)
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	const gatewayErr = ex.Error("exhttp test: bad gateway")
+
+	ex.RegisterCode(gatewayErr, http.StatusBadGateway)
+	ex.RegisterHTTPStatus(gatewayErr, http.StatusBadGateway)
+	ex.RegisterRetryable(gatewayErr)
+
+	rec := httptest.NewRecorder()
+	exhttp.Manifest().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/errors", nil))
+
+	var entries []ex.EntryInfo
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	require.Contains(t, entries, ex.EntryInfo{
+		Slug:       "exhttp_test_bad_gateway",
+		Message:    "exhttp test: bad gateway",
+		Severity:   "",
+		Owner:      "",
+		DocsURL:    "",
+		Code:       http.StatusBadGateway,
+		HTTPStatus: http.StatusBadGateway,
+		Retryable:  true,
+		Deprecated: false,
+	})
+}
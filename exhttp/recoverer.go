@@ -0,0 +1,91 @@
+// Package exhttp provides net/http helpers for ex errors, keeping the core package free of net/http.
+package exhttp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// Option configures the Recoverer.
+type Option func(opts *options)
+
+// options holds the configuration of the Recoverer.
+type options struct {
+	status func(err error) int
+	log    func(r *http.Request, err error)
+}
+
+// WithStatus sets the function choosing the response status of the recovered error (500 by default).
+func WithStatus(status func(err error) int) Option {
+	return func(opts *options) {
+		if status != nil {
+			opts.status = status
+		}
+	}
+}
+
+// WithLog sets the function logging the recovered error. By default the full chain
+// is logged with slog.Default at the error level, along with the method and path of the request.
+func WithLog(log func(r *http.Request, err error)) Option {
+	return func(opts *options) {
+		if log != nil {
+			opts.log = log
+		}
+	}
+}
+
+// Recoverer returns a handler recovering the panics of next, including panics of ex.Critical and ex.Panic.
+// The recovered value is converted with ex.FromPanic, so it is an ErrCritical or ErrUnexpected chain
+// (with the stack of the panic attached), then it is logged and the response is written with the status
+// and its standard text only, so no internals leak to the client.
+//
+// The http.ErrAbortHandler panic is re-raised, as net/http uses it to abort the response silently.
+func Recoverer(next http.Handler, opts ...Option) http.Handler {
+	conf := options{status: internal, log: logDefault}
+
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+
+			if err, ok := value.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(value)
+			}
+
+			err := ex.FromPanic(value)
+			if !ex.HasStack(err) {
+				err = ex.WithStack(err)
+			}
+
+			conf.log(r, err)
+
+			status := conf.status(err)
+			http.Error(w, http.StatusText(status), status)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// internal is the default status of the recovered errors.
+func internal(error) int {
+	return http.StatusInternalServerError
+}
+
+// logDefault logs the recovered error with slog.Default.
+func logDefault(r *http.Request, err error) {
+	slog.Default().ErrorContext(r.Context(), "http: panic recovered",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("error", ex.Dump(err)),
+	)
+}
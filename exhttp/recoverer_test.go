@@ -0,0 +1,109 @@
+package exhttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/exhttp"
+)
+
+func TestRecoverer(t *testing.T) {
+	t.Parallel()
+
+	const (
+		configErr = ex.Error("exhttp test: bad config")
+		limitErr  = ex.Error("exhttp test: rate limited")
+	)
+
+	serve := func(handler http.Handler) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+		return rec
+	}
+
+	t.Run("critical panic", func(t *testing.T) {
+		t.Parallel()
+
+		var logged error
+
+		handler := exhttp.Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			_ = ex.Critical(0, configErr.Reason("missing port"))
+		}), exhttp.WithLog(func(r *http.Request, err error) {
+			require.Equal(t, "/users/42", r.URL.Path)
+
+			logged = err
+		}))
+
+		rec := serve(handler)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Equal(t, "Internal Server Error\n", rec.Body.String())
+		require.EqualError(t, logged, "critical: exhttp test: bad config: missing port")
+		require.ErrorIs(t, logged, configErr)
+		frame, ok := ex.Source(logged)
+
+		require.True(t, ok)
+		require.Contains(t, frame.Function, "TestRecoverer.func2.1")
+	})
+
+	t.Run("any panic", func(t *testing.T) {
+		t.Parallel()
+
+		var logged error
+
+		handler := exhttp.Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		}), exhttp.WithLog(func(_ *http.Request, err error) { logged = err }))
+
+		rec := serve(handler)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.EqualError(t, logged, "unexpected: boom")
+		require.ErrorIs(t, logged, ex.ErrUnexpected)
+	})
+
+	t.Run("mapped status", func(t *testing.T) {
+		t.Parallel()
+
+		handler := exhttp.Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(limitErr)
+		}), exhttp.WithLog(func(*http.Request, error) {}), exhttp.WithStatus(func(err error) int {
+			if errors.Is(err, limitErr) {
+				return http.StatusTooManyRequests
+			}
+
+			return http.StatusInternalServerError
+		}))
+
+		rec := serve(handler)
+
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.Equal(t, "Too Many Requests\n", rec.Body.String())
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		handler := exhttp.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}), exhttp.WithLog(func(*http.Request, error) { t.Error("unexpected log") }), exhttp.WithStatus(nil))
+
+		require.Equal(t, http.StatusNoContent, serve(handler).Code)
+	})
+
+	t.Run("abort handler", func(t *testing.T) {
+		t.Parallel()
+
+		handler := exhttp.Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		}), exhttp.WithLog(nil))
+
+		require.PanicsWithError(t, http.ErrAbortHandler.Error(), func() { serve(handler) })
+	})
+}
@@ -0,0 +1,168 @@
+package exhttp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// ErrStatus represents an HTTP response with a status the client treats as a failure.
+const ErrStatus ex.Error = "unexpected http status"
+
+// defaultSnippet is the default maximum length of the body snippet attached to the errors of the Transport.
+const defaultSnippet = 512
+
+// TransportOption configures the Transport.
+type TransportOption func(opts *transportOptions)
+
+// transportOptions holds the configuration of the Transport.
+type transportOptions struct {
+	classes  []int
+	snippet  int
+	response bool
+}
+
+// WithStatusClasses sets the status classes turned into errors, e.g. 5 for the 5xx statuses (4 and 5 by default).
+func WithStatusClasses(classes ...int) TransportOption {
+	return func(opts *transportOptions) {
+		opts.classes = slices.Clone(classes)
+	}
+}
+
+// WithSnippet sets the maximum length of the body snippet attached to the errors (512 bytes by default).
+// A not positive length turns the snippet off.
+func WithSnippet(n int) TransportOption {
+	return func(opts *transportOptions) {
+		opts.snippet = max(n, 0)
+	}
+}
+
+// WithResponse makes the Transport return the response along with the error, with the body still readable
+// from the start. Note that http.Client drops the response when the error is not nil,
+// so it only helps the callers of RoundTrip itself.
+func WithResponse() TransportOption {
+	return func(opts *transportOptions) {
+		opts.response = true
+	}
+}
+
+// Transport is an http.RoundTripper turning the responses with the failure statuses into ex errors.
+type Transport struct {
+	next http.RoundTripper
+	opts transportOptions
+}
+
+// NewTransport creates a Transport sending the requests with next, or http.DefaultTransport if it is nil.
+func NewTransport(next http.RoundTripper, opts ...TransportOption) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	transport := &Transport{
+		next: next,
+		opts: transportOptions{classes: []int{4, 5}, snippet: defaultSnippet, response: false},
+	}
+
+	for _, opt := range opts {
+		opt(&transport.opts)
+	}
+
+	return transport
+}
+
+// RoundTrip sends the request with the next round tripper. The responses of the configured status classes
+// are turned into the error of FromHTTPStatus with the method, path, status and a body snippet attached
+// as the fields, their bodies are closed (unless WithResponse is set). Other responses pass through untouched.
+// The failures of the next round tripper are translated with ex.TranslateStdlib, so a canceled context
+// is an ex.ErrCanceled error and an exceeded deadline is an ex.ErrTimeout error.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, annotate(ex.TranslateStdlib(err), req)
+	}
+
+	if !slices.Contains(t.opts.classes, resp.StatusCode/100) {
+		return resp, nil
+	}
+
+	snippet := peek(resp, t.opts.snippet)
+
+	err = annotate(FromHTTPStatus(resp.StatusCode), req)
+	err = ex.WithField(ex.WithField(err, "status", resp.StatusCode), "body", snippet)
+
+	if t.opts.response {
+		return resp, err
+	}
+
+	_ = resp.Body.Close()
+
+	return nil, err
+}
+
+// FromHTTPStatus creates the ErrStatus error of the response status, caused by its status line.
+// The well-known statuses are also matchable with the ex identities:
+// 404 and 410 with ex.ErrNotFound, 401 and 403 with ex.ErrPermission, 409 with ex.ErrAlreadyExists,
+// 408 and 504 with ex.ErrTimeout, e.g. ErrStatus.Because(ex.ErrNotFound.Reason("404 Not Found")).
+func FromHTTPStatus(status int) error {
+	line := strconv.Itoa(status)
+	if text := http.StatusText(status); text != "" {
+		line += " " + text
+	}
+
+	switch status {
+	case http.StatusNotFound, http.StatusGone:
+		return ErrStatus.Because(ex.ErrNotFound.Reason(line))
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrStatus.Because(ex.ErrPermission.Reason(line))
+	case http.StatusConflict:
+		return ErrStatus.Because(ex.ErrAlreadyExists.Reason(line))
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrStatus.Because(ex.ErrTimeout.Reason(line))
+	default:
+		return ErrStatus.Reason(line)
+	}
+}
+
+// FromHTTPResponse creates the error of the response with a failure status (not 2xx): its identity
+// is derived from the status, e.g. Error("http 404"), the status is attached as the code (see ex.CodeOf)
+// and the body snippet (up to 512 bytes) is the reason. The body stays readable from the start.
+// If the response is nil or has a 2xx status, the result error will be nil.
+func FromHTTPResponse(resp *http.Response) error {
+	if resp == nil || resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	identity := ex.Error("http " + strconv.Itoa(resp.StatusCode))
+
+	var err error = identity
+	if snippet := peek(resp, defaultSnippet); snippet != "" {
+		err = identity.Reason(snippet)
+	}
+
+	return ex.WithCode(err, resp.StatusCode)
+}
+
+// peek reads up to n bytes of the response body, keeping the body readable from the start.
+func peek(resp *http.Response, n int) string {
+	if resp.Body == nil || n <= 0 {
+		return ""
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, int64(n)))
+
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{Reader: io.MultiReader(bytes.NewReader(snippet), resp.Body), Closer: resp.Body}
+
+	return string(snippet)
+}
+
+// annotate attaches the method and path of the request to the error as the fields.
+func annotate(err error, req *http.Request) error {
+	return ex.WithField(ex.WithField(err, "method", req.Method), "path", req.URL.Path)
+}
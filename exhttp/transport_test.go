@@ -0,0 +1,309 @@
+package exhttp_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/exhttp"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.Error(w, "no such user", http.StatusNotFound)
+		case "/broken":
+			http.Error(w, strings.Repeat("x", 100), http.StatusInternalServerError)
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		default:
+			_, _ = io.WriteString(w, "ok")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	get := func(t *testing.T, ctx context.Context, transport http.RoundTripper, path string) (*http.Response, error) {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+
+		return transport.RoundTrip(req)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := get(t, t.Context(), exhttp.NewTransport(nil), "/users")
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "ok", string(body))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := get(t, t.Context(), exhttp.NewTransport(http.DefaultTransport), "/missing")
+
+		require.Nil(t, resp) //nolint:bodyclose // the body is closed by the transport
+		require.ErrorIs(t, err, exhttp.ErrStatus)
+		require.ErrorIs(t, err, ex.ErrNotFound)
+		require.EqualError(t, err, "unexpected http status: not found: 404 Not Found")
+		require.Equal(t, map[string]any{
+			"method": http.MethodGet,
+			"path":   "/missing",
+			"status": http.StatusNotFound,
+			"body":   "no such user\n",
+		}, ex.Fields(err))
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := get(t, t.Context(), exhttp.NewTransport(nil, exhttp.WithSnippet(10)), "/broken")
+
+		require.Nil(t, resp) //nolint:bodyclose // the body is closed by the transport
+		require.EqualError(t, err, "unexpected http status: 500 Internal Server Error")
+		require.Equal(t, "xxxxxxxxxx", ex.Fields(err)["body"])
+	})
+
+	t.Run("status classes", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := get(t, t.Context(), exhttp.NewTransport(nil, exhttp.WithStatusClasses(5)), "/missing")
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("with response", func(t *testing.T) {
+		t.Parallel()
+
+		transport := exhttp.NewTransport(nil, exhttp.WithSnippet(5), exhttp.WithResponse())
+
+		resp, err := get(t, t.Context(), transport, "/missing")
+		require.ErrorIs(t, err, ex.ErrNotFound)
+		require.Equal(t, "no su", ex.Fields(err)["body"])
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "no such user\n", string(body))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		resp, err := get(t, ctx, exhttp.NewTransport(nil), "/slow")
+
+		require.Nil(t, resp) //nolint:bodyclose // there is no response
+		require.ErrorIs(t, err, ex.ErrTimeout)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, "/slow", ex.Fields(err)["path"])
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		resp, err := get(t, ctx, exhttp.NewTransport(nil), "/slow")
+
+		require.Nil(t, resp) //nolint:bodyclose // there is no response
+		require.ErrorIs(t, err, ex.ErrCanceled)
+	})
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		status int
+	}
+
+	type want struct {
+		identity error
+		message  string
+	}
+
+	tests := []struct {
+		want want
+		name string
+		args args
+	}{
+		{
+			name: "not found",
+			args: args{status: http.StatusGone},
+			want: want{identity: ex.ErrNotFound, message: "unexpected http status: not found: 410 Gone"},
+		},
+		{
+			name: "permission",
+			args: args{status: http.StatusForbidden},
+			want: want{identity: ex.ErrPermission, message: "unexpected http status: permission denied: 403 Forbidden"},
+		},
+		{
+			name: "already exists",
+			args: args{status: http.StatusConflict},
+			want: want{identity: ex.ErrAlreadyExists, message: "unexpected http status: already exists: 409 Conflict"},
+		},
+		{
+			name: "timeout",
+			args: args{status: http.StatusGatewayTimeout},
+			want: want{identity: ex.ErrTimeout, message: "unexpected http status: timeout: 504 Gateway Timeout"},
+		},
+		{
+			name: "other",
+			args: args{status: http.StatusTeapot},
+			want: want{identity: exhttp.ErrStatus, message: "unexpected http status: 418 I'm a teapot"},
+		},
+		{
+			name: "unknown status",
+			args: args{status: 599},
+			want: want{identity: exhttp.ErrStatus, message: "unexpected http status: 599"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := exhttp.FromHTTPStatus(test.args.status)
+
+			require.ErrorIs(t, err, exhttp.ErrStatus)
+			require.ErrorIs(t, err, test.want.identity)
+			require.EqualError(t, err, test.want.message)
+		})
+	}
+}
+
+func TestFromHTTPResponse(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		body   string
+		status int
+	}
+
+	type want struct {
+		identity ex.Error
+		message  string
+		snippet  string
+		code     int
+		failed   bool
+	}
+
+	tests := []struct {
+		want want
+		name string
+		args args
+	}{
+		{
+			name: "not found",
+			args: args{status: http.StatusNotFound, body: `{"error":"no user"}`},
+			want: want{
+				identity: "http 404",
+				message:  `http 404: {"error":"no user"}`,
+				snippet:  `{"error":"no user"}`,
+				code:     404,
+				failed:   true,
+			},
+		},
+		{
+			name: "internal",
+			args: args{status: http.StatusInternalServerError, body: strings.Repeat("x", 1024)},
+			want: want{
+				identity: "http 500",
+				message:  "http 500: " + strings.Repeat("x", 512),
+				snippet:  strings.Repeat("x", 512),
+				code:     500,
+				failed:   true,
+			},
+		},
+		{
+			name: "unavailable",
+			args: args{status: http.StatusServiceUnavailable, body: "down"},
+			want: want{identity: "http 503", message: "http 503: down", snippet: "down", code: 503, failed: true},
+		},
+		{
+			name: "empty body",
+			args: args{status: http.StatusBadGateway, body: ""},
+			want: want{identity: "http 502", message: "http 502", snippet: "", code: 502, failed: true},
+		},
+		{
+			name: "success",
+			args: args{status: http.StatusCreated, body: "created"},
+			want: want{identity: "", message: "", snippet: "", code: 0, failed: false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(test.args.status)
+				_, _ = io.WriteString(w, test.args.body)
+			}))
+			t.Cleanup(server.Close)
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			err = exhttp.FromHTTPResponse(resp)
+
+			body, _ := io.ReadAll(resp.Body)
+			require.Equal(t, test.args.body, string(body))
+
+			if !test.want.failed {
+				require.NoError(t, err)
+
+				return
+			}
+
+			code, ok := ex.CodeOf(err)
+
+			require.ErrorIs(t, err, test.want.identity)
+			require.EqualError(t, err, test.want.message)
+			require.Equal(t, test.want.snippet, ex.CauseString(err))
+			require.True(t, ok)
+			require.Equal(t, test.want.code, code)
+
+			for _, other := range []ex.Error{"http 404", "http 500", "http 503"} {
+				if other != test.want.identity {
+					require.NotErrorIs(t, err, other)
+				}
+			}
+		})
+	}
+
+	require.NoError(t, exhttp.FromHTTPResponse(nil))
+}
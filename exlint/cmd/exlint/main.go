@@ -0,0 +1,12 @@
+// Command exlint runs the exlint analyzers, e.g. `go vet -vettool=$(which exlint) ./...`.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/therenotomorrow/ex/exlint"
+)
+
+func main() {
+	singlechecker.Main(exlint.ErrorCompare)
+}
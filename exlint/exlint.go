@@ -0,0 +1,142 @@
+// Package exlint provides go/analysis analyzers for the code adopting ex errors.
+package exlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Directive suppresses the reports on its line and the line below it, e.g.
+//
+//	if err.Error() == "EOF" { //exlint:ignore the legacy driver returns no sentinel
+const Directive = "//exlint:ignore"
+
+// ErrorCompare reports the checks on the message of an error: comparisons of err.Error() with == and !=,
+// switches over it and the strings.Contains, HasPrefix, HasSuffix and EqualFold calls on it.
+// Such checks break the moment the chain changes, errors.Is with an ex sentinel doesn't.
+// Other uses of err.Error(), like logging it, are not reported.
+//
+//nolint:gochecknoglobals // analyzers are declared as variables by convention
+var ErrorCompare = &analysis.Analyzer{
+	Name:     "errorcompare",
+	Doc:      "report checks on the err.Error() message that should use errors.Is with an ex sentinel",
+	URL:      "https://pkg.go.dev/github.com/therenotomorrow/ex/exlint",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// stringsChecks lists the functions of the strings package reported when called on err.Error().
+//
+//nolint:gochecknoglobals // read-only table
+var stringsChecks = map[string]bool{"Contains": true, "HasPrefix": true, "HasSuffix": true, "EqualFold": true}
+
+// run reports the checks on err.Error() in the files of the pass.
+func run(pass *analysis.Pass) (any, error) {
+	insp, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{(*ast.BinaryExpr)(nil), (*ast.SwitchStmt)(nil), (*ast.CallExpr)(nil)}
+	ignored := suppressed(pass)
+
+	report := func(node ast.Node, message string) {
+		if ignored[line(pass.Fset, node.Pos())] {
+			return
+		}
+
+		pass.Reportf(node.Pos(), "%s; use errors.Is with an ex sentinel instead", message)
+	}
+
+	insp.Preorder(filter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.BinaryExpr:
+			if (node.Op == token.EQL || node.Op == token.NEQ) && (isMessage(pass, node.X) || isMessage(pass, node.Y)) {
+				report(node, "err.Error() compared with "+node.Op.String())
+			}
+		case *ast.SwitchStmt:
+			if node.Tag != nil && isMessage(pass, node.Tag) {
+				report(node, "switch over err.Error()")
+			}
+		case *ast.CallExpr:
+			if name, ok := stringsCall(pass, node); ok && len(node.Args) > 0 && isMessage(pass, node.Args[0]) {
+				report(node, "strings."+name+" on err.Error()")
+			}
+		}
+	})
+
+	return nil, nil //nolint:nilnil // the analyzer has no result
+}
+
+// isMessage reports whether the expression is a call of the Error method of an error, like err.Error().
+func isMessage(pass *analysis.Pass, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Error" {
+		return false
+	}
+
+	selection, ok := pass.TypesInfo.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+
+	errorType, _ := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+	return types.Implements(selection.Recv(), errorType)
+}
+
+// stringsCall returns the name of the reported strings function the call calls.
+func stringsCall(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "strings" || !stringsChecks[fn.Name()] {
+		return "", false
+	}
+
+	return fn.Name(), true
+}
+
+// position identifies a line of a file.
+type position struct {
+	file string
+	line int
+}
+
+// line returns the line of the position.
+func line(fset *token.FileSet, pos token.Pos) position {
+	p := fset.Position(pos)
+
+	return position{file: p.Filename, line: p.Line}
+}
+
+// suppressed returns the lines the Directive suppresses the reports on.
+func suppressed(pass *analysis.Pass) map[position]bool {
+	lines := make(map[position]bool)
+
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if !strings.HasPrefix(comment.Text, Directive) {
+					continue
+				}
+
+				at := line(pass.Fset, comment.Pos())
+				lines[at] = true
+				lines[position{file: at.file, line: at.line + 1}] = true
+			}
+		}
+	}
+
+	return lines
+}
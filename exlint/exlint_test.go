@@ -0,0 +1,15 @@
+package exlint_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/therenotomorrow/ex/exlint"
+)
+
+func TestErrorCompare(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(t, analysistest.TestData(), exlint.ErrorCompare, "a")
+}
@@ -0,0 +1,80 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+type customErr struct{}
+
+func (customErr) Error() string { return "custom" }
+
+type notAnError struct{}
+
+func (notAnError) Error(verbose bool) string { return "not an error" }
+
+func checks(err error) {
+	if err.Error() == "user not found" { // want `err.Error\(\) compared with ==; use errors.Is with an ex sentinel instead`
+		return
+	}
+
+	if "user not found" != err.Error() { // want `err.Error\(\) compared with !=`
+		return
+	}
+
+	if (customErr{}).Error() == "custom" { // want `err.Error\(\) compared with ==`
+		return
+	}
+
+	switch err.Error() { // want `switch over err.Error\(\)`
+	case "timeout":
+		return
+	}
+
+	if strings.Contains(err.Error(), "timeout") { // want `strings.Contains on err.Error\(\)`
+		return
+	}
+
+	if strings.HasPrefix(err.Error(), "db:") { // want `strings.HasPrefix on err.Error\(\)`
+		return
+	}
+}
+
+func allowed(err error) {
+	log.Println(err.Error())
+	log.Printf("failed: %s", err.Error())
+	fmt.Println(strings.ToUpper(err.Error()))
+
+	if errors.Is(err, errors.ErrUnsupported) {
+		return
+	}
+
+	if (notAnError{}).Error(true) == "custom" {
+		return
+	}
+
+	if strings.Contains("timeout", "time") {
+		return
+	}
+
+	msg := err.Error()
+	_ = msg
+
+	switch {
+	case err == nil:
+		return
+	}
+}
+
+func suppressed(err error) {
+	if err.Error() == "EOF" { //exlint:ignore the legacy driver returns no sentinel
+		return
+	}
+
+	//exlint:ignore the message is the contract of the legacy API
+	if strings.Contains(err.Error(), "legacy") {
+		return
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/therenotomorrow/ex/exotel
+
+go 1.25
+
+replace github.com/therenotomorrow/ex => ../
+
+require (
+	github.com/stretchr/testify v1.11.1
+	github.com/therenotomorrow/ex v0.0.0
+	go.opentelemetry.io/otel v1.41.0
+	go.opentelemetry.io/otel/trace v1.41.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,62 @@
+// Package exotel records ex error chains on OpenTelemetry spans, keeping the core package free of otel.
+//
+// Record the error of the operation before ending its span:
+//
+//	if err != nil {
+//		exotel.RecordSpanError(span, err)
+//	}
+//
+// The span gets the Error status with the flattened message, the exception event
+// and the attributes of the outermost identity, code, owner and severity (when present).
+package exotel
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// The attribute keys set by RecordSpanError.
+const (
+	KeyIdentity = attribute.Key("error.identity")
+	KeyCode     = attribute.Key("error.code")
+	KeyOwner    = attribute.Key("error.owner")
+	KeySeverity = attribute.Key("error.severity")
+)
+
+// RecordSpanError sets the status of the span to Error with the flattened message of the error,
+// records the error as the exception event and adds the attributes of the outermost identity,
+// the code (see ex.CodeOf), the owner (see ex.OwnerOf) and the severity (see ex.SeverityOf).
+// Standard errors have no identity, so only the status and the event are recorded for them.
+// If the error is nil (or the span is not recording), nothing is recorded.
+func RecordSpanError(span trace.Span, err error) {
+	if err == nil || span == nil || !span.IsRecording() {
+		return
+	}
+
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	links := ex.ExposeAll(err)
+	if len(links) == 0 || links[0].Foreign {
+		return
+	}
+
+	attrs := []attribute.KeyValue{KeyIdentity.String(links[0].Identity.Error())}
+
+	if code, ok := ex.CodeOf(err); ok {
+		attrs = append(attrs, KeyCode.Int(code))
+	}
+
+	if owner, ok := ex.OwnerOf(err); ok {
+		attrs = append(attrs, KeyOwner.String(owner))
+	}
+
+	if severity, ok := ex.SeverityOf(err); ok {
+		attrs = append(attrs, KeySeverity.String(severity.String()))
+	}
+
+	span.SetAttributes(attrs...)
+}
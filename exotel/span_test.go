@@ -0,0 +1,126 @@
+package exotel_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/exotel"
+)
+
+// span is a trace.Span recording the status, errors and attributes set on it.
+type span struct {
+	noop.Span
+
+	description string
+	errs        []error
+	attrs       []attribute.KeyValue
+	status      codes.Code
+	recording   bool
+}
+
+func (s *span) IsRecording() bool {
+	return s.recording
+}
+
+func (s *span) SetStatus(code codes.Code, description string) {
+	s.status, s.description = code, description
+}
+
+func (s *span) RecordError(err error, _ ...trace.EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *span) SetAttributes(kv ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, kv...)
+}
+
+func recording() *span {
+	return &span{Span: noop.Span{}, description: "", errs: nil, attrs: nil, status: codes.Unset, recording: true}
+}
+
+func TestRecordSpanError(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("exotel test: user not found")
+		dbErr   = ex.Error("exotel test: database error")
+	)
+
+	ex.RegisterCode(userErr, 404)
+	ex.RegisterOwner(userErr, "identity-team")
+	ex.RegisterSeverity(userErr, ex.SeverityWarn)
+
+	t.Run("ex chain", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			got = recording()
+			err = userErr.Because(dbErr.Reason("timeout"))
+		)
+
+		exotel.RecordSpanError(got, err)
+
+		require.Equal(t, codes.Error, got.status)
+		require.Equal(t, "exotel test: user not found: exotel test: database error: timeout", got.description)
+		require.Equal(t, []error{err}, got.errs)
+		require.Equal(t, []attribute.KeyValue{
+			exotel.KeyIdentity.String("exotel test: user not found"),
+			exotel.KeyCode.Int(404),
+			exotel.KeyOwner.String("identity-team"),
+			exotel.KeySeverity.String("warn"),
+		}, got.attrs)
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		t.Parallel()
+
+		got := recording()
+
+		exotel.RecordSpanError(got, dbErr.Reason("timeout"))
+
+		require.Equal(t, codes.Error, got.status)
+		require.Equal(t, []attribute.KeyValue{exotel.KeyIdentity.String("exotel test: database error")}, got.attrs)
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			got = recording()
+			err = errors.New("connection reset")
+		)
+
+		exotel.RecordSpanError(got, err)
+
+		require.Equal(t, codes.Error, got.status)
+		require.Equal(t, "connection reset", got.description)
+		require.Equal(t, []error{err}, got.errs)
+		require.Empty(t, got.attrs)
+	})
+
+	t.Run("nothing recorded", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			got  = recording()
+			idle = recording()
+		)
+
+		idle.recording = false
+
+		exotel.RecordSpanError(got, nil)
+		exotel.RecordSpanError(idle, userErr)
+		exotel.RecordSpanError(nil, userErr)
+
+		require.Equal(t, codes.Unset, got.status)
+		require.Equal(t, codes.Unset, idle.status)
+		require.Empty(t, idle.attrs)
+	})
+}
@@ -0,0 +1,26 @@
+package ex
+
+import "time"
+
+// OnlyCause creates a link with an empty identity, which can't be built with the public API.
+func OnlyCause(cause error) error {
+	return &xError{error: nil, cause: cause, meta: nil, text: "", flags: 0}
+}
+
+// SetClock replaces the clock of the package and returns the function restoring it.
+func SetClock(clock func() time.Time) func() {
+	previous := now
+	now = clock
+
+	return func() {
+		now = previous
+	}
+}
+
+// Cycle creates a link causing itself, which can't be built with the public API.
+func Cycle(identity Error) error {
+	link := &xError{error: identity, cause: nil, meta: nil, text: "", flags: 0}
+	link.cause = link
+
+	return link
+}
@@ -0,0 +1,141 @@
+// Package exslog provides a slog.Handler that expands ex error chains into structured attributes.
+//
+// Wrap any handler with NewHandler and log errors as usual:
+//
+//	logger := slog.New(exslog.NewHandler(slog.NewJSONHandler(os.Stdout, nil)))
+//	logger.Error("request failed", "error", err)
+//
+// Every attribute holding an ex chain is replaced with a group containing its identity, cause, chain,
+// code, owner, duration, fields and stack (when present).
+// Other values, including standard errors, pass through untouched.
+package exslog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/therenotomorrow/ex"
+)
+
+var _ slog.Handler = (*Handler)(nil)
+
+// Option configures the Handler.
+type Option func(opts *options)
+
+// options holds the configuration of the Handler.
+type options struct {
+	stack bool
+}
+
+// WithStack enables (default) or disables the stack in the expanded groups.
+func WithStack(enabled bool) Option {
+	return func(opts *options) {
+		opts.stack = enabled
+	}
+}
+
+// Handler is a slog.Handler that expands ex error chains before passing records to the next handler.
+type Handler struct {
+	next slog.Handler
+	opts options
+}
+
+// NewHandler creates a Handler passing the expanded records to next.
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	handler := &Handler{next: next, opts: options{stack: true}}
+
+	for _, opt := range opts {
+		opt(&handler.opts)
+	}
+
+	return handler
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle expands the attributes of the record and passes it to the next handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	expanded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(h.expand(attr))
+
+		return true
+	})
+
+	return h.next.Handle(ctx, expanded)
+}
+
+// WithAttrs returns a Handler whose next handler has the expanded attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		expanded = append(expanded, h.expand(attr))
+	}
+
+	return &Handler{next: h.next.WithAttrs(expanded), opts: h.opts}
+}
+
+// WithGroup returns a Handler whose next handler starts the group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), opts: h.opts}
+}
+
+// expand replaces the ex chains in the attribute, descending into groups.
+func (h *Handler) expand(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+
+		expanded := make([]slog.Attr, 0, len(group))
+		for _, member := range group {
+			expanded = append(expanded, h.expand(member))
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			if attrs := ex.Attrs(err); attrs != nil {
+				return slog.Attr{Key: attr.Key, Value: h.group(attrs)}
+			}
+		}
+	default:
+	}
+
+	return attr
+}
+
+// group describes the ex chain as a group value, the stack is left out unless it is enabled.
+func (h *Handler) group(attrs []ex.Attr) slog.Value {
+	group := make([]slog.Attr, 0, len(attrs))
+
+	for _, attr := range attrs {
+		if attr.Key == "stack" && !h.opts.stack {
+			continue
+		}
+
+		group = append(group, convert(attr))
+	}
+
+	return slog.GroupValue(group...)
+}
+
+// convert maps the attribute of the chain to the slog attribute, the nested attributes become a group.
+func convert(attr ex.Attr) slog.Attr {
+	nested, ok := attr.Value.([]ex.Attr)
+	if !ok {
+		return slog.Any(attr.Key, attr.Value)
+	}
+
+	group := make([]slog.Attr, 0, len(nested))
+	for _, member := range nested {
+		group = append(group, convert(member))
+	}
+
+	return slog.Attr{Key: attr.Key, Value: slog.GroupValue(group...)}
+}
@@ -0,0 +1,185 @@
+package exslog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/exslog"
+)
+
+const (
+	userErr = ex.Error("exslog test: user not found")
+	dbErr   = ex.Error("exslog test: database error")
+)
+
+func register() {
+	ex.RegisterCode(userErr, 404)
+	ex.RegisterOwner(dbErr, "storage-team")
+}
+
+func chain() error {
+	err := userErr.Because(dbErr.Because(errors.New("connection reset")))
+
+	return ex.WithDuration(ex.WithField(err, "user", 42), 4980*time.Millisecond)
+}
+
+func removeTime(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 && attr.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+
+	return attr
+}
+
+func jsonHandler(w io.Writer) slog.Handler {
+	return slog.NewJSONHandler(w, &slog.HandlerOptions{ReplaceAttr: removeTime})
+}
+
+func TestHandler_JSON(t *testing.T) {
+	t.Parallel()
+
+	register()
+
+	t.Run("ex chain", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf    bytes.Buffer
+			logger = slog.New(exslog.NewHandler(jsonHandler(&buf)))
+		)
+
+		logger.Error("request failed", "error", chain())
+
+		require.JSONEq(t, `{
+			"level": "ERROR",
+			"msg": "request failed",
+			"error": {
+				"identity": "exslog test: user not found",
+				"cause": "connection reset",
+				"chain": ["exslog test: user not found", "exslog test: database error", "connection reset"],
+				"code": 404,
+				"owner": "storage-team",
+				"duration": 4980000000,
+				"fields": {"user": 42}
+			}
+		}`, buf.String())
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf    bytes.Buffer
+			logger = slog.New(exslog.NewHandler(jsonHandler(&buf)))
+		)
+
+		logger.Error("request failed", "error", errors.New("standard"), "sentinel", dbErr)
+
+		require.JSONEq(t, `{
+			"level": "ERROR",
+			"msg": "request failed",
+			"error": "standard",
+			"sentinel": "exslog test: database error"
+		}`, buf.String())
+	})
+
+	t.Run("nested groups", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf    bytes.Buffer
+			logger = slog.New(exslog.NewHandler(jsonHandler(&buf)))
+		)
+
+		logger.
+			WithGroup("request").
+			With("failure", dbErr.Reason("timeout")).
+			Error("request failed", slog.Group("outer", slog.Group("inner", "error", userErr.Reason("no rows"))))
+
+		var got map[string]any
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Equal(t, map[string]any{
+			"level": "ERROR",
+			"msg":   "request failed",
+			"request": map[string]any{
+				"failure": map[string]any{
+					"identity": "exslog test: database error",
+					"cause":    "timeout",
+					"chain":    []any{"exslog test: database error", "timeout"},
+					"owner":    "storage-team",
+				},
+				"outer": map[string]any{
+					"inner": map[string]any{
+						"error": map[string]any{
+							"identity": "exslog test: user not found",
+							"cause":    "no rows",
+							"chain":    []any{"exslog test: user not found", "no rows"},
+							"code":     float64(404),
+						},
+					},
+				},
+			},
+		}, got)
+	})
+
+	t.Run("stack", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf     bytes.Buffer
+			handler = jsonHandler(&buf)
+			err     = ex.WithStack(dbErr.Reason("timeout"))
+			got     struct {
+				Error map[string]any `json:"error"`
+			}
+		)
+
+		slog.New(exslog.NewHandler(handler)).Error("with stack", "error", err)
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.NotEmpty(t, got.Error["stack"])
+
+		buf.Reset()
+
+		got.Error = nil
+
+		slog.New(exslog.NewHandler(handler, exslog.WithStack(false))).Error("without stack", "error", err)
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.NotContains(t, got.Error, "stack")
+	})
+}
+
+func TestHandler_Text(t *testing.T) {
+	t.Parallel()
+
+	register()
+
+	var (
+		buf    bytes.Buffer
+		logger = slog.New(exslog.NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime})))
+	)
+
+	logger.With("service", "users").Info("request failed", "error", chain())
+
+	require.Equal(t, ""+
+		`level=INFO msg="request failed" service=users `+
+		`error.identity="exslog test: user not found" `+
+		`error.cause="connection reset" `+
+		`error.chain="[exslog test: user not found exslog test: database error connection reset]" `+
+		`error.code=404 `+
+		`error.owner=storage-team `+
+		`error.duration=4.98s `+
+		`error.fields.user=42`+"\n", buf.String())
+	require.True(t, logger.Enabled(t.Context(), slog.LevelInfo))
+	require.False(t, logger.Enabled(t.Context(), slog.LevelDebug))
+}
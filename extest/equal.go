@@ -0,0 +1,22 @@
+package extest
+
+import (
+	"testing"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// AssertEqualXError reports an error to t unless the errors are structurally equal, see ex.EqualXError:
+// the same identity messages in the same order and the same cause text. It is the drop-in replacement of
+// require.Equal for error chains, which compares the values and fails on chains built independently.
+func AssertEqualXError(t testing.TB, want, got error) bool {
+	t.Helper()
+
+	if ex.EqualXError(ex.Conv(want), ex.Conv(got)) {
+		return true
+	}
+
+	t.Errorf("equal xerror: structure mismatch:\n\twant: %q\n\thave: %q", ex.Flatten(want), ex.Flatten(got))
+
+	return false
+}
@@ -0,0 +1,59 @@
+package extest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/extest"
+)
+
+func TestAssertEqualXError(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	want := userErr.Because(dbErr.Because(errors.New("timeout")))
+
+	t.Run("built independently", func(t *testing.T) {
+		t.Parallel()
+
+		for _, got := range []error{
+			userErr.Chain(dbErr.Reason("timeout")),
+			ex.WithField(userErr.Because(dbErr.Because(errors.New("timeout"))), "id", 42),
+		} {
+			rec := &recorder{TB: t, errors: nil}
+
+			require.True(t, extest.AssertEqualXError(rec, want, got))
+			require.Empty(t, rec.errors)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{TB: t, errors: nil}
+
+		require.False(t, extest.AssertEqualXError(rec, want, userErr.Reason("timeout")))
+		require.Equal(t, []string{
+			"equal xerror: structure mismatch:\n" +
+				"\twant: [\"user not found\" \"database error\" \"timeout\"]\n" +
+				"\thave: [\"user not found\" \"timeout\"]",
+		}, rec.errors)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{TB: t, errors: nil}
+
+		require.True(t, extest.AssertEqualXError(rec, nil, nil))
+		require.False(t, extest.AssertEqualXError(rec, want, nil))
+		require.Len(t, rec.errors, 1)
+	})
+}
@@ -0,0 +1,66 @@
+// Package extest provides helpers for testing code built on ex errors.
+package extest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// AssertRoundTrip marshals the error chain, unmarshals it back and reports an error to t
+// unless the result is equal to the original by its identity path: the same link messages in the same order,
+// with every Error identity of the original still matchable with errors.Is.
+// It keeps every serializer (JSON, gob, proto and so on) honest with the same assertion.
+func AssertRoundTrip(
+	t testing.TB,
+	err error,
+	marshal func(err error) ([]byte, error),
+	unmarshal func(data []byte) (error, error),
+) bool {
+	t.Helper()
+
+	data, mErr := marshal(err)
+	if mErr != nil {
+		t.Errorf("round trip: marshal %q: %v", err, mErr)
+
+		return false
+	}
+
+	got, uErr := unmarshal(data)
+	if uErr != nil {
+		t.Errorf("round trip: unmarshal %q: %v", data, uErr)
+
+		return false
+	}
+
+	if want, have := path(err), path(got); !slices.Equal(want, have) {
+		t.Errorf("round trip: identity path mismatch:\n\twant: %q\n\thave: %q", want, have)
+
+		return false
+	}
+
+	for _, link := range ex.ExposeAll(err) {
+		var identity ex.Error
+		if errors.As(link.Identity, &identity) && !errors.Is(got, identity) {
+			t.Errorf("round trip: %q does not match the identity %q", got, identity)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// path returns the identity messages of the error chain, from the outermost down.
+func path(err error) []string {
+	links := ex.ExposeAll(err)
+
+	list := make([]string, 0, len(links))
+	for _, link := range links {
+		list = append(list, link.Identity.Error())
+	}
+
+	return list
+}
@@ -0,0 +1,109 @@
+package extest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/extest"
+)
+
+// recorder is a testing.TB that records the reported errors instead of failing the test.
+type recorder struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func marshalJSON(err error) ([]byte, error) {
+	return json.Marshal(ex.Conv(err))
+}
+
+func TestAssertRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{
+			userErr.Because(dbErr.Because(errors.New("connection reset"))),
+			dbErr.Reason("timeout"),
+			dbErr,
+			errors.New("standard"),
+		} {
+			rec := &recorder{TB: t, errors: nil}
+
+			require.True(t, extest.AssertRoundTrip(rec, err, marshalJSON, ex.DecodeJSON))
+			require.Empty(t, rec.errors)
+		}
+	})
+
+	t.Run("lossy unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			rec   = &recorder{TB: t, errors: nil}
+			lossy = func(data []byte) (error, error) {
+				err, decodeErr := ex.DecodeJSON(data)
+
+				return ex.Trim(err, 1), decodeErr
+			}
+		)
+
+		require.False(t, extest.AssertRoundTrip(rec, userErr.Because(dbErr), marshalJSON, lossy))
+		require.Len(t, rec.errors, 1)
+		require.True(t, strings.HasPrefix(rec.errors[0], "round trip: identity path mismatch"))
+	})
+
+	t.Run("renamed identity", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			rec     = &recorder{TB: t, errors: nil}
+			renamed = func([]byte) (error, error) {
+				return ex.Error("user not found").Reason("database error"), nil
+			}
+		)
+
+		require.True(t, extest.AssertRoundTrip(rec, userErr.Because(dbErr), marshalJSON, renamed))
+
+		renamed = func([]byte) (error, error) {
+			return errors.New("user not found"), nil
+		}
+
+		require.False(t, extest.AssertRoundTrip(rec, userErr, marshalJSON, renamed))
+		require.Len(t, rec.errors, 1)
+		require.Contains(t, rec.errors[0], `does not match the identity "user not found"`)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			rec       = &recorder{TB: t, errors: nil}
+			fail      = errors.New("boom")
+			marshal   = func(error) ([]byte, error) { return nil, fail }
+			unmarshal = func([]byte) (error, error) { return nil, fail }
+		)
+
+		require.False(t, extest.AssertRoundTrip(rec, dbErr, marshal, ex.DecodeJSON))
+		require.False(t, extest.AssertRoundTrip(rec, dbErr, marshalJSON, unmarshal))
+		require.Len(t, rec.errors, 2)
+	})
+}
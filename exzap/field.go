@@ -0,0 +1,105 @@
+// Package exzap provides zap fields that encode ex error chains as structured objects.
+//
+// Log errors with Field instead of zap.Error:
+//
+//	logger.Error("request failed", exzap.Field(err))
+//
+// An ex chain is encoded as an object with its identity, cause, chain, code, owner, duration,
+// fields and stack (when present), without reflection or fmt round trips.
+// Standard errors are encoded the same way as zap.Error does.
+package exzap
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/therenotomorrow/ex"
+)
+
+var _ zapcore.ObjectMarshaler = Chain{Err: nil}
+
+// Field returns the zap field of the error under the "error" key.
+func Field(err error) zap.Field {
+	return NamedField("error", err)
+}
+
+// NamedField returns the zap field of the error under the key.
+// If the error is nil, the field is skipped.
+func NamedField(key string, err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+
+	attrs := ex.Attrs(err)
+	if attrs == nil {
+		return zap.NamedError(key, err)
+	}
+
+	return zap.Object(key, object(attrs))
+}
+
+// Chain is a zapcore.ObjectMarshaler of the ex error chain.
+type Chain struct {
+	Err error
+}
+
+// MarshalLogObject encodes the identity, cause, chain, code, owner, duration, fields and stack of the error chain.
+func (c Chain) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return encode(enc, ex.Attrs(c.Err))
+}
+
+// object is a zapcore.ObjectMarshaler of the attributes of the chain already described with ex.Attrs.
+type object []ex.Attr
+
+// MarshalLogObject adds the attributes to the encoder.
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return encode(enc, o)
+}
+
+// encode adds the attributes of the chain to the encoder, the fields become an object.
+func encode(enc zapcore.ObjectEncoder, attrs []ex.Attr) error {
+	for _, attr := range attrs {
+		var err error
+
+		switch value := attr.Value.(type) {
+		case string:
+			enc.AddString(attr.Key, value)
+		case int:
+			enc.AddInt(attr.Key, value)
+		case time.Duration:
+			enc.AddDuration(attr.Key, value)
+		case []string:
+			err = enc.AddArray(attr.Key, strings(value))
+		case []ex.Attr:
+			err = enc.AddObject(attr.Key, zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				for _, member := range value {
+					zap.Any(member.Key, member.Value).AddTo(enc)
+				}
+
+				return nil
+			}))
+		default:
+			zap.Any(attr.Key, value).AddTo(enc)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// strings is a zapcore.ArrayMarshaler of the string slice.
+type strings []string
+
+// MarshalLogArray appends the strings to the encoder.
+func (s strings) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, str := range s {
+		enc.AppendString(str)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package exzap_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/therenotomorrow/ex"
+	"github.com/therenotomorrow/ex/exzap"
+)
+
+const (
+	userErr = ex.Error("exzap test: user not found")
+	dbErr   = ex.Error("exzap test: database error")
+)
+
+func observe(fields ...zap.Field) map[string]any {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	zap.New(core).Error("request failed", fields...)
+
+	return logs.All()[0].ContextMap()
+}
+
+func TestField(t *testing.T) {
+	t.Parallel()
+
+	ex.RegisterCode(userErr, 404)
+	ex.RegisterOwner(dbErr, "storage-team")
+
+	t.Run("ex chain", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.WithField(userErr.Because(dbErr.Because(errors.New("connection reset"))), "user", 42)
+		err = ex.WithDuration(err, 4980*time.Millisecond)
+
+		require.Equal(t, map[string]any{
+			"error": map[string]any{
+				"identity": "exzap test: user not found",
+				"cause":    "connection reset",
+				"chain":    []any{"exzap test: user not found", "exzap test: database error", "connection reset"},
+				"code":     404,
+				"owner":    "storage-team",
+				"duration": 4980 * time.Millisecond,
+				"fields":   map[string]any{"user": int64(42)},
+			},
+		}, observe(exzap.Field(err)))
+	})
+
+	t.Run("stack", func(t *testing.T) {
+		t.Parallel()
+
+		got := observe(exzap.NamedField("failure", ex.WithStack(dbErr.Reason("timeout"))))
+
+		failure, ok := got["failure"].(map[string]any)
+
+		require.True(t, ok)
+		require.NotEmpty(t, failure["stack"])
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, map[string]any{
+			"error":    "standard",
+			"sentinel": "exzap test: database error",
+		}, observe(exzap.Field(errors.New("standard")), exzap.NamedField("sentinel", dbErr)))
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.Empty(t, observe(exzap.Field(nil)))
+	})
+}
@@ -0,0 +1,18 @@
+module github.com/therenotomorrow/ex/exzap
+
+go 1.25
+
+replace github.com/therenotomorrow/ex => ../
+
+require (
+	github.com/stretchr/testify v1.11.1
+	github.com/therenotomorrow/ex v0.0.0
+	go.uber.org/zap v1.28.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
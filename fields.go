@@ -0,0 +1,36 @@
+package ex
+
+// fieldKey is the metadata key of a named field.
+type fieldKey string
+
+// WithField attaches the named value to the outermost link of the error, e.g. for structured logging.
+// The field is never a part of the error message.
+func WithField(err error, name string, value any) error {
+	return attach(err, fieldKey(name), value)
+}
+
+// Fields returns all fields attached to the error chain. When a field is attached more than once,
+// the value of the nearest link wins. If there are no fields, the result will be nil.
+func Fields(err error) map[string]any {
+	var fields map[string]any
+
+	levels, _ := unfold(err)
+	for _, level := range levels {
+		level.meta.each(func(key, value any) {
+			name, ok := key.(fieldKey)
+			if !ok {
+				return
+			}
+
+			if fields == nil {
+				fields = make(map[string]any)
+			}
+
+			if _, ok = fields[string(name)]; !ok {
+				fields[string(name)] = value
+			}
+		})
+	}
+
+	return fields
+}
@@ -0,0 +1,40 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	const (
+		handlerErr = ex.Error("handler failed")
+		userErr    = ex.Error("user not found")
+	)
+
+	t.Run("nearest wins", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = ex.WithField(ex.WithField(userErr.Reason("no rows"), "user", 42), "table", "users")
+			err   = ex.WithField(ex.WithField(handlerErr.Because(inner), "user", 7), "user", 8)
+		)
+
+		require.Equal(t, map[string]any{"user": 8, "table": "users"}, ex.Fields(err))
+		require.Equal(t, map[string]any{"user": 42, "table": "users"}, ex.Fields(inner))
+		require.EqualError(t, err, "handler failed: user not found: no rows")
+	})
+
+	t.Run("no fields", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), ex.WithAttempts(userErr, 3)} {
+			require.Nil(t, ex.Fields(err))
+		}
+	})
+}
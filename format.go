@@ -0,0 +1,106 @@
+package ex
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// details lists the metadata shown by Dump for every link, in order.
+//
+//nolint:gochecknoglobals // read-only table
+var details = []struct {
+	key   any
+	label string
+}{
+	{key: helpKey{}, label: "help"},
+	{key: hintKey{}, label: "hint"},
+	{key: docsKey{}, label: "docs"},
+	{key: ownerKey{}, label: "owner"},
+	{key: positionKey{}, label: "position"},
+	{key: attemptsKey{}, label: "attempts"},
+	{key: durationKey{}, label: "duration"},
+	{key: goroutineKey{}, label: "goroutine"},
+	{key: occurrenceKey{}, label: "occurrence"},
+	{key: relatedKey{}, label: "related"},
+	{key: stackKey{}, label: "stack"},
+}
+
+// Format implements fmt.Formatter. The %s and %v verbs print the error message, %q prints it quoted,
+// %x and %X print it in hex, and %+v prints the detailed view of the chain, the same as Dump.
+// Any other verb is reported the way fmt does it, for example %!d(*ex.xError=message).
+func (e *xError) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			_, _ = io.WriteString(state, Dump(e))
+
+			return
+		}
+
+		_, _ = io.WriteString(state, e.Error())
+	case 's':
+		_, _ = io.WriteString(state, e.Error())
+	case 'q', 'x', 'X':
+		_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), e.Error())
+	default:
+		// the same shape as fmt uses for a verb the operand doesn't support
+		_, _ = fmt.Fprintf(state, "%%!%c(%T=%s)", verb, e, e.Error())
+	}
+}
+
+// Dump returns the detailed multi-line view of the error chain: every link on its own line,
+// followed by its indented details (help, hint, docs, owner, position, attempts, duration, stack and so on).
+// Unlike the Error method it keeps the control characters of messages as is.
+// If the error is nil, the result will be empty.
+func Dump(err error) string {
+	var (
+		builder      strings.Builder
+		levels, tail = unfold(hooked(err))
+	)
+
+	line := func(text string) {
+		if builder.Len() > 0 {
+			builder.WriteByte('\n')
+		}
+
+		builder.WriteString(text)
+	}
+
+	for _, level := range levels {
+		line(truncate(level.error.Error()))
+
+		for _, detail := range details {
+			if value, ok := level.find(detail.key); ok {
+				line(describe(detail.label, value))
+			}
+		}
+	}
+
+	if text, ok := tail.(block); ok {
+		// the lines of the block are indented under the link, so they don't look like links
+		if text != "" {
+			line("    " + strings.ReplaceAll(truncate(string(text)), "\n", "\n    "))
+		}
+	} else if tail != nil {
+		line(truncate(tail.Error()))
+
+		for _, detail := range details {
+			if value, ok := registered(tail, detail.key); ok {
+				line(describe(detail.label, value))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// describe renders the detail line, multi-line values start on the next line.
+func describe(label string, value any) string {
+	text := fmt.Sprint(value)
+	if !strings.HasPrefix(text, "\n") {
+		text = " " + text
+	}
+
+	return "    " + label + ":" + text
+}
@@ -0,0 +1,45 @@
+package ex
+
+import (
+	"bytes"
+	"runtime"
+)
+
+// goroutineKey is the metadata key of the goroutine identifier.
+type goroutineKey struct{}
+
+// goroutineHeader is the size of the buffer holding the header line of the goroutine stack.
+const goroutineHeader = 64
+
+// TagGoroutine attaches the identifier of the current goroutine to the outermost link of the error,
+// e.g. to correlate which goroutine produced the error in aggregated logs. The identifier is shown by Dump.
+//
+// The identifier is derived from the goroutine stack, so it is meant for debugging only: Go deliberately
+// doesn't expose goroutine identities, and the identifiers are reused after goroutines exit.
+// Never use them for the program logic.
+func TagGoroutine(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return attach(err, goroutineKey{}, goroutineID())
+}
+
+// GoroutineID returns the goroutine identifier of the nearest link of the error chain tagged with TagGoroutine.
+func GoroutineID(err error) (string, bool) {
+	return lookup[string](err, goroutineKey{})
+}
+
+// goroutineID parses the identifier from the header line of the current goroutine stack,
+// e.g. "goroutine 42 [running]:".
+func goroutineID() string {
+	buf := make([]byte, goroutineHeader)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if id, _, ok := bytes.Cut(buf, []byte(" ")); ok {
+		return string(id)
+	}
+
+	return ""
+}
@@ -0,0 +1,58 @@
+package ex_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestGoroutineID(t *testing.T) {
+	t.Parallel()
+
+	const workerErr = ex.Error("worker failed")
+
+	t.Run("captured", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			here  = ex.TagGoroutine(workerErr.Reason("here"))
+			there = make(chan error)
+		)
+
+		go func() {
+			there <- ex.TagGoroutine(workerErr.Reason("there"))
+		}()
+
+		hereID, ok := ex.GoroutineID(ex.Unexpected(here))
+
+		require.True(t, ok)
+		require.NotEmpty(t, hereID)
+
+		_, err := strconv.ParseUint(hereID, 10, 64)
+		require.NoError(t, err)
+
+		thereID, ok := ex.GoroutineID(<-there)
+
+		require.True(t, ok)
+		require.NotEqual(t, hereID, thereID)
+		require.EqualError(t, here, "worker failed: here")
+		require.Equal(t, "worker failed\n    goroutine: "+hereID+"\nhere", ex.Dump(here))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), workerErr.Reason("untagged")} {
+			id, ok := ex.GoroutineID(err)
+
+			require.False(t, ok)
+			require.Empty(t, id)
+		}
+
+		require.NoError(t, ex.TagGoroutine(nil))
+	})
+}
@@ -0,0 +1,24 @@
+package ex
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// handledHook holds the function set with SetHandledHook.
+//
+//nolint:gochecknoglobals // the hook is process-wide by design
+var handledHook atomic.Pointer[func(err error, frame runtime.Frame)]
+
+// SetHandledHook sets the function invoked with the error and the frame of the caller every time Handled is called,
+// e.g. to collect the acknowledged errors and their places while testing. A nil function unsets the hook.
+// The hook is invoked only in the debug builds (the "exdebug" build tag), in the release builds Handled is a no-op.
+func SetHandledHook(fn func(err error, frame runtime.Frame)) {
+	if fn == nil {
+		handledHook.Store(nil)
+
+		return
+	}
+
+	handledHook.Store(&fn)
+}
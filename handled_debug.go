@@ -0,0 +1,22 @@
+//go:build exdebug
+
+package ex
+
+import "runtime"
+
+// Handled marks the error as intentionally dealt with, a stronger signal than Skip: the caller considered
+// the error and acknowledges it, e.g. after logging it or falling back to a default value.
+// Static analyzers treat the calls of Handled (and Skip) as the uses of the error.
+//
+// In the release builds it is a no-op without any overhead, in the debug builds (the "exdebug" build tag)
+// it passes the error and the frame of the caller to the hook set with SetHandledHook.
+func Handled(err error) {
+	hook := handledHook.Load()
+	if hook == nil || err == nil {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames(callers(1)).Next()
+
+	(*hook)(err, frame)
+}
@@ -0,0 +1,44 @@
+//go:build exdebug
+
+package ex_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+//nolint:paralleltest // sets the process-wide hook
+func TestHandled(t *testing.T) {
+	var (
+		errs   []error
+		frames []runtime.Frame
+	)
+
+	ex.SetHandledHook(func(err error, frame runtime.Frame) {
+		errs = append(errs, err)
+		frames = append(frames, frame)
+	})
+	defer ex.SetHandledHook(nil)
+
+	handledErr := errors.New("handled")
+
+	ex.Handled(handledErr)
+	ex.Handled(nil)
+
+	_, _, line, _ := runtime.Caller(0)
+
+	require.Equal(t, []error{handledErr}, errs)
+	require.Len(t, frames, 1)
+	require.Equal(t, "github.com/therenotomorrow/ex_test.TestHandled", frames[0].Function)
+	require.Equal(t, line-3, frames[0].Line)
+
+	ex.SetHandledHook(nil)
+	ex.Handled(handledErr)
+
+	require.Len(t, errs, 1)
+}
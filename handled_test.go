@@ -0,0 +1,28 @@
+//go:build !exdebug
+
+package ex_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+//nolint:paralleltest // sets the process-wide hook
+func TestHandled(t *testing.T) {
+	var calls int
+
+	ex.SetHandledHook(func(_ error, _ runtime.Frame) {
+		calls++
+	})
+	defer ex.SetHandledHook(nil)
+
+	ex.Handled(errors.New("handled"))
+	ex.Handled(nil)
+
+	require.Zero(t, calls)
+}
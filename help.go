@@ -0,0 +1,63 @@
+package ex
+
+// helpKey is the metadata key of the help text.
+type helpKey struct{}
+
+// docsKey is the metadata key of the documentation URL.
+type docsKey struct{}
+
+// hintKey is the metadata key of the remediation hint.
+type hintKey struct{}
+
+// WithHelp attaches the help text (like a runbook step) to the outermost link of the error.
+// The text is shown by Dump and %+v, but it is never a part of the error message.
+func WithHelp(err error, text string) error {
+	return attach(err, helpKey{}, text)
+}
+
+// WithDocsURL attaches the documentation URL to the outermost link of the error.
+// The URL is shown by Dump and %+v, but it is never a part of the error message.
+func WithDocsURL(err error, url string) error {
+	return attach(err, docsKey{}, url)
+}
+
+// WithHint attaches the remediation hint for support engineers to the outermost link of the error,
+// e.g. "check that DATABASE_URL is reachable from the pod".
+// The hint is shown by Dump and %+v, but it is never a part of the error message.
+func WithHint(err error, hint string) error {
+	return attach(err, hintKey{}, hint)
+}
+
+// RegisterHelp registers the help text of the identity, so every link of a chain with the identity inherits it.
+func RegisterHelp(identity Error, text string) {
+	register(identity, helpKey{}, text)
+}
+
+// RegisterDocsURL registers the documentation URL of the identity,
+// so every link of a chain with the identity inherits it.
+func RegisterDocsURL(identity Error, url string) {
+	register(identity, docsKey{}, url)
+}
+
+// RegisterHint registers the remediation hint of the identity, so every link of a chain with the identity inherits it.
+func RegisterHint(identity Error, hint string) {
+	register(identity, hintKey{}, hint)
+}
+
+// HelpOf returns the help text of the nearest link of the error chain that has one.
+// The text attached to the link wins over the one registered against its identity.
+func HelpOf(err error) (string, bool) {
+	return lookup[string](err, helpKey{})
+}
+
+// DocsURLOf returns the documentation URL of the nearest link of the error chain that has one.
+// The URL attached to the link wins over the one registered against its identity.
+func DocsURLOf(err error) (string, bool) {
+	return lookup[string](err, docsKey{})
+}
+
+// Hint returns the remediation hint of the nearest link of the error chain that has one.
+// The hint attached to the link wins over the one registered against its identity.
+func Hint(err error) (string, bool) {
+	return lookup[string](err, hintKey{})
+}
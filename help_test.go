@@ -0,0 +1,159 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestHelpOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		migrationErr = ex.Error("help test: migration failed")
+		dirtyErr     = ex.Error("help test: dirty database")
+	)
+
+	ex.RegisterHelp(dirtyErr, "run migrate force")
+	ex.RegisterDocsURL(dirtyErr, "https://runbooks.local/dirty")
+
+	t.Run("per sentinel", func(t *testing.T) {
+		t.Parallel()
+
+		err := migrationErr.Because(ex.Conv(dirtyErr))
+
+		help, ok := ex.HelpOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, "run migrate force", help)
+
+		url, ok := ex.DocsURLOf(dirtyErr)
+
+		require.True(t, ok)
+		require.Equal(t, "https://runbooks.local/dirty", url)
+	})
+
+	t.Run("per instance", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = ex.WithHelp(ex.Conv(dirtyErr), "call the on-call DBA")
+			err   = ex.WithDocsURL(migrationErr.Because(inner), "https://runbooks.local/migrations")
+		)
+
+		help, ok := ex.HelpOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, "call the on-call DBA", help)
+
+		url, ok := ex.DocsURLOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, "https://runbooks.local/migrations", url)
+		require.EqualError(t, err, "help test: migration failed: help test: dirty database")
+	})
+
+	t.Run("no help", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), migrationErr} {
+			help, ok := ex.HelpOf(err)
+
+			require.False(t, ok)
+			require.Empty(t, help)
+		}
+	})
+}
+
+func TestHint(t *testing.T) {
+	t.Parallel()
+
+	const (
+		connectErr = ex.Error("hint test: connect failed")
+		queryErr   = ex.Error("hint test: query failed")
+	)
+
+	ex.RegisterHint(connectErr, "check that DATABASE_URL is reachable from the pod")
+
+	t.Run("registered", func(t *testing.T) {
+		t.Parallel()
+
+		err := queryErr.Because(connectErr.Reason("timeout"))
+
+		hint, ok := ex.Hint(err)
+
+		require.True(t, ok)
+		require.Equal(t, "check that DATABASE_URL is reachable from the pod", hint)
+		require.EqualError(t, err, "hint test: query failed: hint test: connect failed: timeout")
+		require.Equal(t, ""+
+			"hint test: query failed\n"+
+			"hint test: connect failed\n"+
+			"    hint: check that DATABASE_URL is reachable from the pod\n"+
+			"timeout", ex.Dump(err))
+	})
+
+	t.Run("instance overrides registered", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.WithHint(connectErr.Reason("timeout"), "the database is in maintenance")
+
+		hint, ok := ex.Hint(queryErr.Because(err))
+
+		require.True(t, ok)
+		require.Equal(t, "the database is in maintenance", hint)
+		require.Equal(t, ""+
+			"hint test: connect failed\n"+
+			"    hint: the database is in maintenance\n"+
+			"timeout", fmt.Sprintf("%+v", err))
+	})
+
+	t.Run("no hint", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), queryErr} {
+			hint, ok := ex.Hint(err)
+
+			require.False(t, ok)
+			require.Empty(t, hint)
+		}
+	})
+}
+
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	const (
+		migrationErr = ex.Error("dump test: migration failed")
+		dirtyErr     = ex.Error("dump test: dirty database")
+	)
+
+	ex.RegisterDocsURL(dirtyErr, "https://runbooks.local/dirty")
+
+	var (
+		causeErr = errors.New("version 42\nis dirty")
+		err      = ex.WithHelp(migrationErr.Because(dirtyErr.Because(causeErr)), "run migrate force")
+		want     = "" +
+			"dump test: migration failed\n" +
+			"    help: run migrate force\n" +
+			"dump test: dirty database\n" +
+			"    docs: https://runbooks.local/dirty\n" +
+			"version 42\n" +
+			"is dirty"
+	)
+
+	require.Equal(t, want, ex.Dump(err))
+	require.Equal(t, want, fmt.Sprintf("%+v", err))
+	require.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+	require.Equal(t, err.Error(), fmt.Sprintf("%s", err))
+	require.Equal(t, fmt.Sprintf("%q", err.Error()), fmt.Sprintf("%q", err))
+	require.Equal(t, fmt.Sprintf("%x", err.Error()), fmt.Sprintf("%x", err))
+	require.Equal(t, fmt.Sprintf("% X", err.Error()), fmt.Sprintf("% X", err))
+	require.Equal(t, "%!d(*ex.xError="+err.Error()+")", fmt.Sprintf("%d", err))
+	require.Equal(t, "dump test: dirty database\n    docs: https://runbooks.local/dirty", ex.Dump(dirtyErr))
+	require.Equal(t, "standard", ex.Dump(errors.New("standard")))
+	require.Empty(t, ex.Dump(nil))
+}
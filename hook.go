@@ -0,0 +1,100 @@
+package ex
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// criticalHooks holds the functions registered with OnCritical.
+//
+//nolint:gochecknoglobals // hooks are process-wide by design
+var criticalHooks struct {
+	hooks []*func(err error)
+	mu    sync.Mutex
+}
+
+// panicDisabled reports whether panics are turned off with SetPanicMode.
+//
+//nolint:gochecknoglobals // the mode is process-wide by design
+var panicDisabled atomic.Bool
+
+// exposeHook holds the function set with SetExposeHook.
+//
+//nolint:gochecknoglobals // the hook is process-wide by design
+var exposeHook atomic.Pointer[func(err error)]
+
+// SetExposeHook sets the function invoked synchronously with the error every time Expose is called on an ex error,
+// e.g. for auditing who inspects error internals and how often. A nil function unsets the hook.
+// When the hook is unset, Expose pays only for an atomic load.
+func SetExposeHook(fn func(err error)) {
+	if fn == nil {
+		exposeHook.Store(nil)
+
+		return
+	}
+
+	exposeHook.Store(&fn)
+}
+
+// exposed runs the hook set with SetExposeHook against the error.
+func exposed(err error) {
+	if hook := exposeHook.Load(); hook != nil {
+		(*hook)(err)
+	}
+}
+
+// SetPanicMode enables (default) or disables panics of Panic and Critical for the whole process.
+//
+// With panics disabled the critical error is still passed to the OnCritical hooks,
+// but then Panic just returns and Critical returns the zero value instead of panicking.
+// This lets the same code run safely in a server, at a price: the caller carries on with
+// a zero value it never expected to see. Disable panics only with hooks in place that
+// report the failure, otherwise critical errors silently disappear.
+func SetPanicMode(enabled bool) {
+	panicDisabled.Store(!enabled)
+}
+
+// OnCritical registers a hook invoked synchronously with the critical error just before Critical or Panic panics.
+// Useful as a last-gasp point to flush logs or emit metrics. Hooks run in registration order,
+// and a nil cause (which doesn't panic) never invokes them.
+// The returned function unregisters the hook, e.g. in test cleanup.
+func OnCritical(fn func(err error)) func() {
+	if fn == nil {
+		return func() {}
+	}
+
+	hook := &fn
+
+	criticalHooks.mu.Lock()
+	defer criticalHooks.mu.Unlock()
+
+	criticalHooks.hooks = append(criticalHooks.hooks, hook)
+
+	return func() {
+		criticalHooks.mu.Lock()
+		defer criticalHooks.mu.Unlock()
+
+		// a fresh slice, so the hooks being run by critical stay untouched
+		hooks := make([]*func(err error), 0, len(criticalHooks.hooks))
+
+		for _, registered := range criticalHooks.hooks {
+			if registered != hook {
+				hooks = append(hooks, registered)
+			}
+		}
+
+		criticalHooks.hooks = hooks
+	}
+}
+
+// critical runs the registered hooks against the error. Hooks are called outside the lock,
+// so they are free to register other hooks.
+func critical(err error) {
+	criticalHooks.mu.Lock()
+	hooks := criticalHooks.hooks
+	criticalHooks.mu.Unlock()
+
+	for _, hook := range hooks {
+		(*hook)(err)
+	}
+}
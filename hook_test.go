@@ -0,0 +1,137 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+//nolint:paralleltest // registers process-wide hooks
+func TestOnCritical(t *testing.T) {
+	const hookErr = ex.Error("hook error")
+
+	var calls []string
+
+	record := func(name string) func(err error) {
+		return func(err error) {
+			if errors.Is(err, hookErr) {
+				calls = append(calls, name+": "+err.Error())
+			}
+		}
+	}
+
+	t.Cleanup(ex.OnCritical(record("first")))
+	t.Cleanup(ex.OnCritical(nil))
+
+	removeSecond := ex.OnCritical(record("second"))
+	t.Cleanup(removeSecond)
+
+	t.Run("no panic", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			ex.Panic(nil)
+
+			_ = ex.Critical(42, nil)
+		})
+
+		require.Empty(t, calls)
+	})
+
+	t.Run("with panic", func(t *testing.T) {
+		require.PanicsWithError(t, "critical: hook error", func() {
+			ex.Panic(hookErr)
+		})
+
+		require.Equal(t, []string{
+			"first: critical: hook error",
+			"second: critical: hook error",
+		}, calls)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		calls = nil
+
+		removeSecond()
+		removeSecond()
+
+		require.PanicsWithError(t, "critical: hook error", func() {
+			ex.Panic(hookErr)
+		})
+
+		require.Equal(t, []string{"first: critical: hook error"}, calls)
+	})
+}
+
+//nolint:paralleltest // toggles the process-wide panic mode
+func TestSetPanicMode(t *testing.T) {
+	const modeErr = ex.Error("mode error")
+
+	var calls int
+
+	t.Cleanup(ex.OnCritical(func(err error) {
+		if errors.Is(err, modeErr) {
+			calls++
+		}
+	}))
+
+	t.Run("disabled", func(t *testing.T) {
+		ex.SetPanicMode(false)
+		t.Cleanup(func() { ex.SetPanicMode(true) })
+
+		require.NotPanics(t, func() {
+			got := ex.Critical(42, modeErr)
+
+			require.Zero(t, got)
+
+			ex.Panic(modeErr)
+		})
+
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		ex.SetPanicMode(true)
+
+		require.PanicsWithError(t, "critical: mode error", func() {
+			_ = ex.Critical(42, modeErr)
+		})
+
+		require.Equal(t, 3, calls)
+	})
+}
+
+//nolint:paralleltest // sets the process-wide expose hook
+func TestSetExposeHook(t *testing.T) {
+	const auditErr = ex.Error("audit error")
+
+	var calls []error
+
+	ex.SetExposeHook(func(err error) {
+		if errors.Is(err, auditErr) {
+			calls = append(calls, err)
+		}
+	})
+	t.Cleanup(func() { ex.SetExposeHook(nil) })
+
+	var (
+		chain   = auditErr.Reason("who")
+		wrapped = fmt.Errorf("wrapped: %w", chain)
+	)
+
+	_, _ = ex.Expose(chain)
+	_, _ = ex.Expose(wrapped)
+	_, _ = ex.Expose(auditErr)
+	_, _ = ex.Expose(errors.New("standard"))
+	_, _ = ex.Expose(nil)
+
+	require.Equal(t, []error{chain, wrapped}, calls)
+
+	ex.SetExposeHook(nil)
+
+	_, _ = ex.Expose(chain)
+
+	require.Len(t, calls, 2)
+}
@@ -0,0 +1,112 @@
+package ex
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// FromFieldMap converts a field→message map (e.g. from a validator) into an error chain under the identity.
+// Each field is rendered as "field: message", fields are sorted for stable output.
+// The fields are plain text matching ErrInvalidField, so the user-provided field names never become
+// identities: errors.Is(err, ErrInvalidField) holds, while errors.Is(err, ex.Error("email")) doesn't.
+// If the map is empty, the result error will be nil.
+func FromFieldMap(identity Error, fields map[string]string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(fields))
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		errs = append(errs, invalidField(name+Separator+fields[name]))
+	}
+
+	return identity.Because(&List{errs: errs})
+}
+
+// invalidField is a field of FromFieldMap rendered as "field: message".
+type invalidField string
+
+// Error returns the text of the field.
+func (f invalidField) Error() string {
+	return string(f)
+}
+
+// Is matches ErrInvalidField.
+func (f invalidField) Is(target error) bool {
+	return target == ErrInvalidField
+}
+
+// FromSlice converts errors collected from a concurrent fan-out into an error chain under the identity.
+// Nil errors are filtered out, the rest are matchable individually with errors.Is and errors.As.
+// If all errors are nil, the result error will also be nil.
+func FromSlice(identity Error, errs []error) error {
+	causes := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		if err != nil {
+			causes = append(causes, err)
+		}
+	}
+
+	if len(causes) == 0 {
+		return nil
+	}
+
+	return identity.Because(&List{errs: causes})
+}
+
+// List is a list of errors rendered on a single line, each of them is matchable with errors.Is and errors.As.
+// It deliberately has no Unwrap method, so the chain traversal treats it as a single terminal cause.
+type List struct {
+	errs []error
+}
+
+// Errors returns a copy of the errors of the list.
+func (l *List) Errors() []error {
+	return slices.Clone(l.errs)
+}
+
+// Error joins the messages of all errors with a semicolon.
+func (l *List) Error() string {
+	var builder strings.Builder
+
+	for i, err := range l.errs {
+		if i > 0 {
+			builder.WriteString("; ")
+		}
+
+		builder.WriteString(err.Error())
+	}
+
+	return builder.String()
+}
+
+// Is checks if the target error matches any of the joined errors.
+func (l *List) Is(target error) bool {
+	for _, err := range l.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first of the joined errors matching the target, like errors.As does for each of them.
+// The ex chains of the errors are not reachable as the internal chain links, so the chain traversal
+// still treats the list as a single terminal cause.
+func (l *List) As(target any) bool {
+	if _, ok := target.(**xError); ok {
+		return false
+	}
+
+	for _, err := range l.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
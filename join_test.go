@@ -0,0 +1,108 @@
+package ex_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestFromFieldMap(t *testing.T) {
+	t.Parallel()
+
+	const validationErr = ex.Error("validation failed")
+
+	t.Run("empty map", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.FromFieldMap(validationErr, nil))
+		require.NoError(t, ex.FromFieldMap(validationErr, map[string]string{}))
+	})
+
+	t.Run("deterministic order", func(t *testing.T) {
+		t.Parallel()
+
+		fields := map[string]string{
+			"name":  "too short",
+			"email": "is missing",
+			"age":   "must be positive",
+		}
+
+		for range 10 {
+			err := ex.FromFieldMap(validationErr, fields)
+
+			require.EqualError(t, err, "validation failed: age: must be positive; email: is missing; name: too short")
+		}
+	})
+
+	t.Run("fields are not identities", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.FromFieldMap(validationErr, map[string]string{"email": "is missing"})
+
+		require.ErrorIs(t, err, validationErr)
+		require.ErrorIs(t, err, ex.ErrInvalidField)
+		require.NotErrorIs(t, err, ex.Error("email"))
+		require.NotErrorIs(t, err, ex.Error("is missing"))
+		require.NotErrorIs(t, err, ex.Error("email: is missing"))
+	})
+}
+
+func TestFromSlice(t *testing.T) {
+	t.Parallel()
+
+	const fanoutErr = ex.Error("fan-out failed")
+
+	t.Run("all nil", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.FromSlice(fanoutErr, nil))
+		require.NoError(t, ex.FromSlice(fanoutErr, make([]error, 3)))
+	})
+
+	t.Run("some failed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			firstErr  = errors.New("first failed")
+			secondErr = ex.Error("second failed").Reason("timeout")
+			results   = []error{nil, firstErr, nil, secondErr}
+			err       = ex.FromSlice(fanoutErr, results)
+		)
+
+		require.EqualError(t, err, "fan-out failed: first failed; second failed: timeout")
+		require.ErrorIs(t, err, fanoutErr)
+		require.ErrorIs(t, err, firstErr)
+		require.ErrorIs(t, err, ex.Error("second failed"))
+		require.ErrorIs(t, err, ex.Error("timeout"))
+	})
+
+	t.Run("typed causes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			opErr   = &net.OpError{Op: "dial", Net: "tcp", Source: nil, Addr: nil, Err: errors.New("refused")}
+			pathErr = &fs.PathError{Op: "open", Path: "/etc/app", Err: fs.ErrNotExist}
+			err     = ex.FromSlice(fanoutErr, []error{errors.New("first failed"), opErr, pathErr})
+		)
+
+		var gotOp *net.OpError
+
+		require.ErrorAs(t, err, &gotOp)
+		require.Same(t, opErr, gotOp)
+
+		var gotPath *fs.PathError
+
+		require.ErrorAs(t, err, &gotPath)
+		require.Same(t, pathErr, gotPath)
+
+		var gotSyntax *json.SyntaxError
+
+		require.NotErrorAs(t, err, &gotSyntax)
+	})
+}
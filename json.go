@@ -0,0 +1,171 @@
+package ex
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonLink is the JSON representation of a chain link with its cause nested into it.
+// The duration (see WithDuration) is encoded on the outermost link only.
+type jsonLink struct {
+	Cause    *jsonLink `json:"cause,omitempty"`
+	Error    string    `json:"error"`
+	Duration string    `json:"duration,omitempty"`
+}
+
+// jsonLine is the JSON representation of a chain node written by JSONLines.
+// Identity nodes carry the identity, the standard error terminating the chain carries the cause.
+type jsonLine struct {
+	Identity *string `json:"identity,omitempty"`
+	Cause    *string `json:"cause,omitempty"`
+	Depth    int     `json:"depth"`
+	Terminal bool    `json:"terminal"`
+}
+
+// MarshalJSON encodes the error chain as nested links, e.g.
+// {"error":"user not found","cause":{"error":"database error","cause":{"error":"connection reset"}}}.
+func (e *xError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(encode(e))
+}
+
+// DecodeJSON decodes the error chain encoded by MarshalJSON. Every link becomes an Error identity,
+// the last one is the cause of the chain, like Parse does for the flattened message.
+// The duration of the outermost link is restored with WithDuration.
+// If the data is the JSON null, the result error will be nil.
+func DecodeJSON(data []byte) (error, error) {
+	var link *jsonLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		return nil, err
+	}
+
+	if link == nil {
+		return nil, nil
+	}
+
+	duration, durationErr := time.ParseDuration(link.Duration)
+
+	var list []string
+	for ; link != nil; link = link.Cause {
+		list = append(list, link.Error)
+	}
+
+	if durationErr != nil {
+		return build(list), nil
+	}
+
+	return WithDuration(build(list), duration), nil
+}
+
+// JSONLines encodes the error chain as newline-delimited JSON, one object per node from the outermost down, e.g.
+//
+//	{"identity":"user not found","depth":0,"terminal":false}
+//	{"identity":"database error","depth":1,"terminal":false}
+//	{"cause":"connection reset","depth":2,"terminal":true}
+//
+// Unlike MarshalJSON (nested links) every node is queryable on its own, which suits line-based log processors.
+// The nodes are the links of ExposeAll: a standard error terminating the chain has the cause instead of
+// the identity, while a terminating Error (like the text of Reason) stays an identity node.
+// Like ExposeAll, the chain is transformed with the hook set with SetRenderHook first.
+// If the error is nil, the result will be nil.
+func JSONLines(err error) []byte {
+	var buf []byte
+
+	links := ExposeAll(err)
+
+	for depth, link := range links {
+		var (
+			text = truncate(link.Identity.Error())
+			line = jsonLine{Identity: &text, Cause: nil, Depth: depth, Terminal: depth == len(links)-1}
+		)
+
+		if _, ok := link.Identity.(Error); link.Foreign && !ok {
+			line.Identity, line.Cause = nil, &text
+		}
+
+		// the line has only strings, an int and a bool, so it always encodes
+		data, _ := json.Marshal(line)
+
+		buf = append(append(buf, data...), '\n')
+	}
+
+	return buf
+}
+
+// EncodeJSONStream reads errors from the channel until it is closed and writes them to w
+// as a JSON array of encoded chains, without holding the errors in memory.
+// Nil errors are skipped. If w can be flushed (like bufio.Writer or http.Flusher),
+// it is flushed after every error. On the first failure it still reads the channel until it is closed,
+// so the producer never blocks, and then returns the failure.
+func EncodeJSONStream(w io.Writer, errs <-chan error) error {
+	fail := func(err error) error {
+		for range errs {
+			// the producer is released, the rest of the errors can't be written anyway
+		}
+
+		return err
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return fail(err)
+	}
+
+	count := 0
+
+	for chain := range errs {
+		if chain == nil {
+			continue
+		}
+
+		data, err := json.Marshal(encode(chain))
+		if err != nil {
+			return fail(err)
+		}
+
+		if count > 0 {
+			data = append([]byte(","), data...)
+		}
+
+		if _, err = w.Write(data); err != nil {
+			return fail(err)
+		}
+
+		if err = flush(w); err != nil {
+			return fail(err)
+		}
+
+		count++
+	}
+
+	_, err := io.WriteString(w, "]")
+
+	return err
+}
+
+// encode converts the error chain into nested links.
+func encode(err error) *jsonLink {
+	var link *jsonLink
+
+	list, _ := messages(err, truncate)
+	for i := len(list) - 1; i >= 0; i-- {
+		link = &jsonLink{Cause: link, Error: list[i], Duration: ""}
+	}
+
+	if duration, ok := DurationOf(err); ok && link != nil {
+		link.Duration = duration.String()
+	}
+
+	return link
+}
+
+// flush flushes the writer if it supports flushing.
+func flush(w io.Writer) error {
+	switch flusher := w.(type) {
+	case interface{ Flush() error }:
+		return flusher.Flush()
+	case interface{ Flush() }:
+		flusher.Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package ex_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	chain := userErr.Because(dbErr.Because(errors.New("connection reset")))
+
+	data, err := json.Marshal(chain)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"error": "user not found",
+		"cause": {"error": "database error", "cause": {"error": "connection reset"}}
+	}`, string(data))
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(userErr.Because(dbErr.Because(errors.New("connection reset"))))
+
+		require.NoError(t, err)
+
+		got, err := ex.DecodeJSON(data)
+
+		require.NoError(t, err)
+		require.EqualError(t, got, "user not found: database error: connection reset")
+		require.ErrorIs(t, got, userErr)
+		require.ErrorIs(t, got, dbErr)
+		require.ErrorIs(t, got, ex.Error("connection reset"))
+	})
+
+	t.Run("single link", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ex.DecodeJSON([]byte(`{"error": "database error"}`))
+
+		require.NoError(t, err)
+		require.Equal(t, dbErr, got)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ex.DecodeJSON([]byte(`null`))
+
+		require.NoError(t, err)
+		require.NoError(t, got)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ex.DecodeJSON([]byte(`{"error":`))
+
+		require.Error(t, err)
+		require.NoError(t, got)
+	})
+}
+
+func TestJSONLines(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		want string
+		args args
+	}{
+		{
+			name: "standard cause",
+			args: args{err: userErr.Because(dbErr.Because(errors.New("connection reset")))},
+			want: `{"identity":"user not found","depth":0,"terminal":false}` + "\n" +
+				`{"identity":"database error","depth":1,"terminal":false}` + "\n" +
+				`{"cause":"connection reset","depth":2,"terminal":true}` + "\n",
+		},
+		{
+			name: "reason",
+			args: args{err: userErr.Reason("no rows")},
+			want: `{"identity":"user not found","depth":0,"terminal":false}` + "\n" +
+				`{"identity":"no rows","depth":1,"terminal":true}` + "\n",
+		},
+		{
+			name: "identity",
+			args: args{err: dbErr},
+			want: `{"identity":"database error","depth":0,"terminal":true}` + "\n",
+		},
+		{
+			name: "standard error",
+			args: args{err: errors.New("connection reset")},
+			want: `{"cause":"connection reset","depth":0,"terminal":true}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.JSONLines(test.args.err)
+
+			require.Equal(t, test.want, string(got))
+		})
+	}
+
+	require.Nil(t, ex.JSONLines(nil))
+}
+
+func TestEncodeJSONStream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no errors", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf  bytes.Buffer
+			errs = make(chan error)
+		)
+
+		close(errs)
+
+		require.NoError(t, ex.EncodeJSONStream(&buf, errs))
+		require.JSONEq(t, `[]`, buf.String())
+	})
+
+	t.Run("few errors", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf    bytes.Buffer
+			writer = bufio.NewWriter(&buf)
+			errs   = make(chan error)
+		)
+
+		go func() {
+			defer close(errs)
+
+			errs <- ex.Error("payment failed").Reason("card declined")
+			errs <- nil
+			errs <- errors.New("standard error")
+			errs <- ex.Conv(ex.Error("base error"))
+		}()
+
+		require.NoError(t, ex.EncodeJSONStream(writer, errs))
+		require.NoError(t, writer.Flush())
+		require.True(t, json.Valid(buf.Bytes()))
+		require.JSONEq(t, `[
+			{"error": "payment failed", "cause": {"error": "card declined"}},
+			{"error": "standard error"},
+			{"error": "base error"}
+		]`, buf.String())
+	})
+	t.Run("failing writer", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			writeErr = errors.New("disk full")
+			errs     = make(chan error)
+			produced = make(chan struct{})
+		)
+
+		go func() {
+			defer close(produced)
+			defer close(errs)
+
+			for range 3 {
+				errs <- ex.Error("payment failed")
+			}
+		}()
+
+		require.ErrorIs(t, ex.EncodeJSONStream(&failingWriter{err: writeErr, left: 1}, errs), writeErr)
+
+		// the producer is not left blocked on the unbuffered channel
+		<-produced
+	})
+}
+
+// failingWriter is a fake writer failing after the number of writes.
+type failingWriter struct {
+	err  error
+	left int
+}
+
+func (w *failingWriter) Write(data []byte) (int, error) {
+	if w.left == 0 {
+		return 0, w.err
+	}
+
+	w.left--
+
+	return len(data), nil
+}
@@ -0,0 +1,40 @@
+package ex
+
+// Translator translates the link messages of error chains.
+// Implement it to plug in any message catalog (like golang.org/x/text) without the package depending on it.
+type Translator interface {
+	// Translate returns the translation of the canonical message into the locale, if there is one.
+	Translate(locale, message string) (string, bool)
+}
+
+// Catalog is a Translator backed by a map of locale → canonical message → translation.
+type Catalog map[string]map[string]string
+
+// Translate returns the translation of the canonical message into the locale, if there is one.
+func (c Catalog) Translate(locale, message string) (string, bool) {
+	text, ok := c[locale][message]
+
+	return text, ok
+}
+
+// Localize renders the error chain like the Error method does, but with link messages translated
+// into the locale using the catalog of locale → canonical message → translation.
+// Messages without a translation are rendered as is. The error itself is never changed,
+// so errors.Is keeps working against the untranslated sentinels.
+func Localize(err error, locale string, catalog map[string]map[string]string) string {
+	return LocalizeWith(err, locale, Catalog(catalog))
+}
+
+// LocalizeWith renders the error chain like the Error method does, but with link messages translated
+// into the locale using the translator. Messages without a translation are rendered as is.
+func LocalizeWith(err error, locale string, translator Translator) string {
+	list, isBlock := messages(err, func(message string) string {
+		if text, ok := translator.Translate(locale, message); ok {
+			return render(text)
+		}
+
+		return render(message)
+	})
+
+	return join(list, isBlock)
+}
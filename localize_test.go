@@ -0,0 +1,92 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	var (
+		ioErr   = errors.New("connection reset")
+		err     = userErr.Because(dbErr.Because(ioErr))
+		catalog = map[string]map[string]string{
+			"de": {"user not found": "Benutzer nicht gefunden", "database error": "Datenbankfehler"},
+			"fr": {"user not found": "utilisateur introuvable"},
+		}
+	)
+
+	type args struct {
+		locale string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "full translation",
+			args: args{locale: "de"},
+			want: "Benutzer nicht gefunden: Datenbankfehler: connection reset",
+		},
+		{
+			name: "missing translations",
+			args: args{locale: "fr"},
+			want: "utilisateur introuvable: database error: connection reset",
+		},
+		{
+			name: "unknown locale",
+			args: args{locale: "es"},
+			want: "user not found: database error: connection reset",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.Localize(err, test.args.locale, catalog)
+
+			require.Equal(t, test.want, got)
+			require.ErrorIs(t, err, userErr)
+			require.ErrorIs(t, err, dbErr)
+			require.EqualError(t, err, "user not found: database error: connection reset")
+		})
+	}
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.Empty(t, ex.Localize(nil, "de", catalog))
+	})
+}
+
+type upperTranslator struct{}
+
+func (upperTranslator) Translate(locale, message string) (string, bool) {
+	if locale != "shout" || message != "user not found" {
+		return "", false
+	}
+
+	return "USER NOT FOUND", true
+}
+
+func TestLocalizeWith(t *testing.T) {
+	t.Parallel()
+
+	err := ex.Error("user not found").Reason("no rows")
+
+	require.Equal(t, "USER NOT FOUND: no rows", ex.LocalizeWith(err, "shout", upperTranslator{}))
+	require.Equal(t, "user not found: no rows", ex.LocalizeWith(err, "whisper", upperTranslator{}))
+}
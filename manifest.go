@@ -0,0 +1,54 @@
+package ex
+
+import (
+	"cmp"
+	"slices"
+)
+
+// EntryInfo describes an identity in the Manifest, the machine-readable contract of the errors a service
+// can return. Metadata that is not registered is omitted from the JSON.
+type EntryInfo struct {
+	Slug       string `json:"slug"`                  // The stable label of the identity, see LabelKey.
+	Message    string `json:"message"`               // The message of the identity.
+	Severity   string `json:"severity,omitempty"`    // The severity registered with RegisterSeverity.
+	Owner      string `json:"owner,omitempty"`       // The owner registered with RegisterOwner.
+	DocsURL    string `json:"docs,omitempty"`        // The documentation URL registered with RegisterDocsURL.
+	Code       int    `json:"code,omitempty"`        // The code registered with RegisterCode.
+	HTTPStatus int    `json:"http_status,omitempty"` // The HTTP status registered with RegisterHTTPStatus.
+	Retryable  bool   `json:"retryable,omitempty"`   // Whether the identity is registered with RegisterRetryable.
+	Deprecated bool   `json:"deprecated,omitempty"`  // Whether the identity is registered with RegisterDeprecated.
+}
+
+// Manifest returns the entries of every identity with registered metadata, sorted by the slug
+// (and the message for the equal slugs), e.g. to publish the errors a service can return to client teams.
+// It is built from the registry at the call time, so it reflects every registration made so far.
+func Manifest() []EntryInfo {
+	infos := Registry()
+	entries := make([]EntryInfo, 0, len(infos))
+
+	for _, info := range infos {
+		entry := EntryInfo{
+			Slug:       LabelKey(info.Identity),
+			Message:    string(info.Identity),
+			Severity:   "",
+			Owner:      info.Owner,
+			DocsURL:    info.DocsURL,
+			Code:       info.Code,
+			HTTPStatus: info.HTTPStatus,
+			Retryable:  info.Retryable,
+			Deprecated: info.Deprecated,
+		}
+
+		if info.Severity != 0 {
+			entry.Severity = info.Severity.String()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	slices.SortFunc(entries, func(a, b EntryInfo) int {
+		return cmp.Or(cmp.Compare(a.Slug, b.Slug), cmp.Compare(a.Message, b.Message))
+	})
+
+	return entries
+}
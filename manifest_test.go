@@ -0,0 +1,97 @@
+package ex_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	const (
+		notFoundErr = ex.Error("manifest test: not found")
+		limitErr    = ex.Error("manifest test: rate limited")
+		conflictErr = ex.Error("manifest test: Conflict")
+	)
+
+	ex.RegisterCode(notFoundErr, 404)
+	ex.RegisterDocsURL(notFoundErr, "https://example.com/errors/not-found")
+	ex.RegisterSeverity(limitErr, ex.SeverityWarn)
+	ex.RegisterOwner(limitErr, "edge-team")
+	ex.RegisterCode(limitErr, 1042)
+	ex.RegisterHTTPStatus(limitErr, 429)
+	ex.RegisterRetryable(limitErr)
+	ex.RegisterHelp(conflictErr, "reload and retry")
+	ex.RegisterDeprecated(conflictErr)
+
+	var entries []ex.EntryInfo
+
+	for _, entry := range ex.Manifest() {
+		if strings.HasPrefix(entry.Message, "manifest test: ") {
+			entries = append(entries, entry)
+		}
+	}
+
+	require.Equal(t, []ex.EntryInfo{
+		{
+			Slug:       "manifest_test_conflict",
+			Message:    "manifest test: Conflict",
+			Severity:   "",
+			Owner:      "",
+			DocsURL:    "",
+			Code:       0,
+			HTTPStatus: 0,
+			Retryable:  false,
+			Deprecated: true,
+		},
+		{
+			Slug:       "manifest_test_not_found",
+			Message:    "manifest test: not found",
+			Severity:   "",
+			Owner:      "",
+			DocsURL:    "https://example.com/errors/not-found",
+			Code:       404,
+			HTTPStatus: 0,
+			Retryable:  false,
+			Deprecated: false,
+		},
+		{
+			Slug:       "manifest_test_rate_limited",
+			Message:    "manifest test: rate limited",
+			Severity:   "warn",
+			Owner:      "edge-team",
+			DocsURL:    "",
+			Code:       1042,
+			HTTPStatus: 429,
+			Retryable:  true,
+			Deprecated: false,
+		},
+	}, entries)
+
+	data, err := json.Marshal(entries)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"slug": "manifest_test_conflict", "message": "manifest test: Conflict", "deprecated": true},
+		{
+			"slug": "manifest_test_not_found",
+			"message": "manifest test: not found",
+			"code": 404,
+			"docs": "https://example.com/errors/not-found"
+		},
+		{
+			"slug": "manifest_test_rate_limited",
+			"message": "manifest test: rate limited",
+			"code": 1042,
+			"http_status": 429,
+			"retryable": true,
+			"severity": "warn",
+			"owner": "edge-team"
+		}
+	]`, string(data))
+}
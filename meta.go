@@ -0,0 +1,126 @@
+package ex
+
+// meta is an immutable list of values attached to an xError.
+// Attaching a value prepends a new node, so the values reachable from existing errors never change.
+type meta struct {
+	key   any   // The key of the value, usually an unexported struct type.
+	value any   // The attached value.
+	next  *meta // The values attached before (can be nil).
+}
+
+// with returns the list extended with the key/value pair.
+func (m *meta) with(key, value any) *meta {
+	return &meta{key: key, value: value, next: m}
+}
+
+// find returns the most recently attached value of the key.
+func (m *meta) find(key any) (any, bool) {
+	for node := m; node != nil; node = node.next {
+		if node.key == key {
+			return node.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// each calls fn for every attached value, from the most recently attached.
+func (m *meta) each(fn func(key, value any)) {
+	for node := m; node != nil; node = node.next {
+		fn(node.key, node.value)
+	}
+}
+
+// attach returns a copy of the error with the key/value pair attached to its outermost level.
+// A standard error becomes the identity of a new xError.
+// If the error is nil, the result error will also be nil.
+func attach(err error, key, value any) error {
+	if err == nil {
+		return nil
+	}
+
+	clone := xError{error: err, cause: nil, meta: nil, text: "", flags: 0}
+	if xer, ok := err.(*xError); ok {
+		clone = *xer
+	}
+
+	clone.meta = clone.meta.with(key, value)
+
+	return &clone
+}
+
+// MergeMeta copies the metadata of the src chain (fields, codes, request, attempts and so on) onto
+// the outermost link of dst, so no context is lost when errors from two sources are coalesced.
+// Both the values attached to the links of src and the ones registered against its identities are copied,
+// the nearest link of src wins within it. On conflict dst wins: a key that dst already has anywhere in its chain
+// (attached or registered) is not copied. Neither error is changed.
+// If dst is nil, the result error will also be nil.
+func MergeMeta(dst, src error) error {
+	if dst == nil {
+		return nil
+	}
+
+	clone := xError{error: dst, cause: nil, meta: nil, text: "", flags: 0}
+	if xer, ok := dst.(*xError); ok {
+		clone = *xer
+	}
+
+	merged := false
+	seen := make(map[any]bool)
+
+	copyMeta := func(key, value any) {
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+
+		if _, ok := lookup[any](dst, key); !ok {
+			clone.meta = clone.meta.with(key, value)
+			merged = true
+		}
+	}
+
+	levels, tail := unfold(src)
+	for _, level := range levels {
+		level.meta.each(copyMeta)
+		entry(level.error).each(copyMeta)
+	}
+
+	entry(tail).each(copyMeta)
+
+	if !merged {
+		return dst
+	}
+
+	return &clone
+}
+
+// lookup returns the value of the key attached to the nearest level of the chain
+// or registered against its identity.
+func lookup[T any](err error, key any) (T, bool) {
+	levels, tail := unfold(err)
+
+	for _, level := range levels {
+		if value, ok := level.find(key); ok {
+			return value.(T), true //nolint:forcetypeassert // keys are bound to their value types
+		}
+	}
+
+	if value, ok := registered(tail, key); ok {
+		return value.(T), true //nolint:forcetypeassert // keys are bound to their value types
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// find returns the value of the key attached to the level or registered against its identity.
+func (e *xError) find(key any) (any, bool) {
+	if value, ok := e.meta.find(key); ok {
+		return value, true
+	}
+
+	return registered(e.error, key)
+}
@@ -0,0 +1,184 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestMetadataCopyOnWrite(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("meta test: user not found")
+		dbErr   = ex.Error("meta test: database error")
+	)
+
+	shared := ex.WithField(ex.WithAttempts(userErr.Because(dbErr.Reason("timeout")), 1), "user", 42)
+
+	t.Run("attaching keeps the original", func(t *testing.T) {
+		t.Parallel()
+
+		derived := ex.WithField(ex.WithAttempts(shared, 5), "user", 7)
+
+		attempts, _ := ex.Attempts(shared)
+
+		require.Equal(t, 1, attempts)
+		require.Equal(t, map[string]any{"user": 42}, ex.Fields(shared))
+		require.Equal(t, map[string]any{"user": 7}, ex.Fields(derived))
+	})
+
+	t.Run("returned fields are a copy", func(t *testing.T) {
+		t.Parallel()
+
+		fields := ex.Fields(shared)
+		fields["user"] = 0
+
+		require.Equal(t, map[string]any{"user": 42}, ex.Fields(shared))
+	})
+
+	t.Run("concurrent wrapping and reading", func(t *testing.T) {
+		t.Parallel()
+
+		var wg sync.WaitGroup
+
+		for i := range 8 {
+			wg.Go(func() {
+				for j := range 100 {
+					err := ex.WithField(shared, "worker", i)
+					err = ex.WithStack(ex.WithAttempts(err, j))
+					err = ex.Conv(err).Because(errors.New(strconv.Itoa(j)))
+					err = ex.Freeze(ex.AddCause(err, errors.New("extra")))
+
+					fields := ex.Fields(err)
+
+					assert.Equal(t, i, fields["worker"])
+					assert.Equal(t, 42, fields["user"])
+				}
+			})
+
+			wg.Go(func() {
+				for range 100 {
+					attempts, ok := ex.Attempts(shared)
+
+					assert.True(t, ok)
+					assert.Equal(t, 1, attempts)
+					assert.Equal(t, "meta test: user not found: meta test: database error: timeout", shared.Error())
+					assert.Equal(t, map[string]any{"user": 42}, ex.Fields(shared))
+					assert.NotEmpty(t, fmt.Sprintf("%+v", shared))
+					assert.NotEmpty(t, ex.Dump(shared))
+				}
+			})
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("concurrent registration", func(t *testing.T) {
+		t.Parallel()
+
+		const codeErr = ex.Error("meta test: registered")
+
+		var wg sync.WaitGroup
+
+		for i := range 8 {
+			wg.Go(func() {
+				ex.RegisterCode(codeErr, 400+i)
+				ex.RegisterHelp(codeErr, "retry later")
+			})
+
+			wg.Go(func() {
+				_, _ = ex.CodeOf(codeErr.Reason("read"))
+				_, _ = ex.HelpOf(codeErr)
+				_ = ex.Breadcrumbs(shared)
+			})
+		}
+
+		wg.Wait()
+
+		code, ok := ex.CodeOf(codeErr)
+
+		require.True(t, ok)
+		require.GreaterOrEqual(t, code, 400)
+	})
+}
+
+func TestMergeMeta(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiErr   = ex.Error("merge test: api error")
+		userErr  = ex.Error("merge test: user not found")
+		queueErr = ex.Error("merge test: queue error")
+	)
+
+	ex.RegisterCode(userErr, 404)
+	ex.RegisterCode(queueErr, 503)
+
+	src := ex.WithAttempts(ex.WithField(ex.WithField(queueErr.Reason("full"), "user", 2), "order", 3), 5)
+
+	t.Run("merged", func(t *testing.T) {
+		t.Parallel()
+
+		dst := ex.WithField(apiErr.Reason("timeout"), "user", 1)
+		got := ex.MergeMeta(dst, src)
+
+		require.Equal(t, map[string]any{"user": 1, "order": 3}, ex.Fields(got))
+
+		code, ok := ex.CodeOf(got)
+
+		require.True(t, ok)
+		require.Equal(t, 503, code)
+
+		attempts, ok := ex.Attempts(got)
+
+		require.True(t, ok)
+		require.Equal(t, 5, attempts)
+		require.EqualError(t, got, "merge test: api error: timeout")
+		require.Equal(t, map[string]any{"user": 1}, ex.Fields(dst))
+	})
+
+	t.Run("dst wins", func(t *testing.T) {
+		t.Parallel()
+
+		dst := ex.WithAttempts(apiErr.Because(userErr.Reason("no rows")), 1)
+		got := ex.MergeMeta(dst, src)
+
+		code, ok := ex.CodeOf(got)
+
+		require.True(t, ok)
+		require.Equal(t, 404, code)
+
+		attempts, ok := ex.Attempts(got)
+
+		require.True(t, ok)
+		require.Equal(t, 1, attempts)
+		require.Equal(t, map[string]any{"user": 2, "order": 3}, ex.Fields(got))
+	})
+
+	t.Run("standard errors", func(t *testing.T) {
+		t.Parallel()
+
+		dst := errors.New("standard")
+		got := ex.MergeMeta(dst, src)
+
+		require.EqualError(t, got, "standard")
+		require.ErrorIs(t, got, dst)
+		require.Equal(t, map[string]any{"user": 2, "order": 3}, ex.Fields(got))
+		require.Same(t, dst, ex.MergeMeta(dst, errors.New("no metadata")))
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.MergeMeta(nil, src))
+		require.Same(t, src, ex.MergeMeta(src, nil))
+	})
+}
@@ -0,0 +1,77 @@
+package ex
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// occurrenceKey is the metadata key of the occurrence.
+type occurrenceKey struct{}
+
+// occurrences counts the occurrences minted by Error.New and Error.Newf.
+//
+//nolint:gochecknoglobals // the counter is process-wide by design
+var occurrences atomic.Uint64
+
+// occurrencePrefix tells apart the occurrence IDs of different processes.
+//
+//nolint:gochecknoglobals // generated once per process
+var occurrencePrefix = func() string {
+	buf := make([]byte, 4)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}()
+
+// Occurrence describes a single failure minted from a sentinel with Error.New or Error.Newf.
+type Occurrence struct {
+	Time  time.Time     // The time the occurrence was minted.
+	ID    string        // The identifier unique to the occurrence, e.g. "1f2e3d4c-42".
+	Frame runtime.Frame // The place the occurrence was minted at.
+}
+
+// String renders the occurrence as shown by Dump, e.g. "1f2e3d4c-42 at main.load (/app/main.go:42)".
+func (o Occurrence) String() string {
+	return o.ID + " at " + o.Frame.Function + " (" + o.Frame.File + ":" + strconv.Itoa(o.Frame.Line) + ")"
+}
+
+// New mints a fresh occurrence of the Error: it still matches the Error with errors.Is, but it is a distinct
+// value carrying its own Occurrence (unique ID, time and caller location), so two failures with the same
+// identity can be told apart in logs. The occurrence survives Because, Reason and wrapping.
+func (c Error) New() XError {
+	return &xError{error: c, cause: nil, meta: (*meta)(nil).with(occurrenceKey{}, occur()), text: "", flags: 0}
+}
+
+// Newf mints a fresh occurrence of the Error like New does, with the formatted text as its reason.
+func (c Error) Newf(format string, args ...any) XError {
+	cause, inline := reason(fmt.Sprintf(format, args...))
+
+	return &xError{
+		error: c,
+		cause: cause,
+		meta:  (*meta)(nil).with(occurrenceKey{}, occur()),
+		text:  inline,
+		flags: flagReason,
+	}
+}
+
+// OccurrenceOf returns the occurrence of the nearest link of the error chain minted with Error.New or Error.Newf.
+func OccurrenceOf(err error) (Occurrence, bool) {
+	return lookup[Occurrence](err, occurrenceKey{})
+}
+
+// occur creates the occurrence of the caller of the caller.
+func occur() Occurrence {
+	frame, _ := runtime.CallersFrames(callers(2)).Next()
+
+	return Occurrence{
+		Time:  now(),
+		ID:    occurrencePrefix + "-" + strconv.FormatUint(occurrences.Add(1), 10),
+		Frame: frame,
+	}
+}
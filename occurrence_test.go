@@ -0,0 +1,90 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestError_New(t *testing.T) {
+	t.Parallel()
+
+	const uploadErr = ex.Error("upload failed")
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			first  = uploadErr.New()
+			second = uploadErr.New()
+		)
+
+		require.ErrorIs(t, first, uploadErr)
+		require.ErrorIs(t, second, uploadErr)
+		require.NotSame(t, first, second)
+		require.EqualError(t, first, "upload failed")
+		require.NotErrorIs(t, first, second)
+	})
+
+	t.Run("distinct occurrences", func(t *testing.T) {
+		t.Parallel()
+
+		first, ok := ex.OccurrenceOf(uploadErr.New())
+		require.True(t, ok)
+
+		_, _, line, _ := runtime.Caller(0)
+		second, ok := ex.OccurrenceOf(uploadErr.Newf("chunk %d", 3))
+		require.True(t, ok)
+
+		require.NotEqual(t, first.ID, second.ID)
+		require.Equal(t, "github.com/therenotomorrow/ex_test.TestError_New.func2", second.Frame.Function)
+		require.Equal(t, line+1, second.Frame.Line)
+		require.WithinDuration(t, time.Now(), second.Time, time.Minute)
+		require.Equal(t, fmt.Sprintf("%s at %s (%s:%d)",
+			second.ID, second.Frame.Function, second.Frame.File, second.Frame.Line), second.String())
+	})
+
+	t.Run("because", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			occurrence = uploadErr.New()
+			err        = ex.Error("sync failed").Because(occurrence.Because(errors.New("disk full")))
+		)
+
+		want, ok := ex.OccurrenceOf(occurrence)
+		require.True(t, ok)
+
+		got, ok := ex.OccurrenceOf(err)
+		require.True(t, ok)
+
+		require.Equal(t, want, got)
+		require.ErrorIs(t, err, uploadErr)
+		require.EqualError(t, err, "sync failed: upload failed: disk full")
+		require.Contains(t, ex.Dump(err), "upload failed\n    occurrence: "+want.String()+"\n")
+	})
+
+	t.Run("newf", func(t *testing.T) {
+		t.Parallel()
+
+		err := uploadErr.Newf("chunk %d of %d", 3, 5)
+
+		require.EqualError(t, err, "upload failed: chunk 3 of 5")
+		require.ErrorIs(t, err, uploadErr)
+		require.True(t, ex.IsReason(err))
+	})
+
+	t.Run("sentinel", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := ex.OccurrenceOf(uploadErr.Reason("shared"))
+
+		require.False(t, ok)
+	})
+}
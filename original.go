@@ -0,0 +1,26 @@
+package ex
+
+// originalKey is the metadata key of the original error passed to ConvKeep.
+type originalKey struct{}
+
+// ConvKeep converts a standard error into an XError like Conv does, and additionally keeps the exact error passed,
+// so Original can return it later, e.g. for errors.As into its concrete type.
+func ConvKeep(err error) XError {
+	if err == nil {
+		return nil
+	}
+
+	clone := xError{error: err, cause: nil, meta: nil, text: "", flags: 0}
+	if xer, ok := asXError(err); ok {
+		clone = *xer
+	}
+
+	clone.meta = clone.meta.with(originalKey{}, err)
+
+	return &clone
+}
+
+// Original returns the error passed to ConvKeep, kept by the nearest link of the error chain that has one.
+func Original(err error) (error, bool) {
+	return lookup[error](err, originalKey{})
+}
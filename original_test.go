@@ -0,0 +1,82 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestConvKeep(t *testing.T) {
+	t.Parallel()
+
+	const (
+		readErr = ex.Error("original test: read failed")
+		cfgErr  = ex.Error("original test: config error")
+	)
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			pathErr = &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+			err     = ex.ConvKeep(pathErr)
+		)
+
+		original, ok := ex.Original(cfgErr.Because(err.Reason("missing")))
+
+		require.True(t, ok)
+		require.Same(t, pathErr, original)
+
+		var target *fs.PathError
+
+		require.ErrorAs(t, original, &target)
+		require.Equal(t, "config.yaml", target.Path)
+		require.Equal(t, ex.Conv(pathErr).Error(), err.Error())
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("ex error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain = readErr.Reason("timeout")
+			err   = ex.ConvKeep(chain)
+		)
+
+		original, ok := ex.Original(err)
+
+		require.True(t, ok)
+		require.Same(t, chain, original)
+		require.EqualError(t, err, "original test: read failed: timeout")
+		require.True(t, ex.IsReason(err))
+	})
+
+	t.Run("wrapped ex error", func(t *testing.T) {
+		t.Parallel()
+
+		wrapped := fmt.Errorf("load: %w", readErr.Reason("timeout"))
+
+		original, ok := ex.Original(ex.ConvKeep(wrapped))
+
+		require.True(t, ok)
+		require.Same(t, wrapped, original)
+	})
+
+	t.Run("nothing kept", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, ex.ConvKeep(nil))
+
+		for _, err := range []error{nil, errors.New("standard"), ex.Conv(errors.New("standard")), readErr} {
+			original, ok := ex.Original(err)
+
+			require.False(t, ok)
+			require.NoError(t, original)
+		}
+	})
+}
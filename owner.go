@@ -0,0 +1,21 @@
+package ex
+
+// ownerKey is the metadata key of the owner.
+type ownerKey struct{}
+
+// WithOwner attaches the owner (like the team responsible for the error class) to the outermost link of the error,
+// e.g. for routing alerts to the on-call of the team.
+func WithOwner(err error, owner string) error {
+	return attach(err, ownerKey{}, owner)
+}
+
+// RegisterOwner registers the owner of the identity, so every link of a chain with the identity inherits it.
+func RegisterOwner(identity Error, owner string) {
+	register(identity, ownerKey{}, owner)
+}
+
+// OwnerOf returns the owner of the nearest link of the error chain that has one.
+// The owner attached to the link wins over the one registered against its identity.
+func OwnerOf(err error) (string, bool) {
+	return lookup[string](err, ownerKey{})
+}
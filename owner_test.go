@@ -0,0 +1,63 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestOwnerOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		declinedErr = ex.Error("owner test: billing declined")
+		checkoutErr = ex.Error("owner test: checkout failed")
+	)
+
+	ex.RegisterOwner(declinedErr, "payments-team")
+
+	t.Run("registered", func(t *testing.T) {
+		t.Parallel()
+
+		owner, ok := ex.OwnerOf(checkoutErr.Because(declinedErr.Reason("insufficient funds")))
+
+		require.True(t, ok)
+		require.Equal(t, "payments-team", owner)
+	})
+
+	t.Run("instance overrides registered", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.WithOwner(declinedErr.Reason("fraud"), "risk-team")
+
+		owner, ok := ex.OwnerOf(checkoutErr.Because(err))
+
+		require.True(t, ok)
+		require.Equal(t, "risk-team", owner)
+	})
+
+	t.Run("nearest wins", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.WithOwner(checkoutErr.Because(declinedErr), "storefront-team")
+
+		owner, ok := ex.OwnerOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, "storefront-team", owner)
+	})
+
+	t.Run("no owner", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), checkoutErr.Reason("why")} {
+			owner, ok := ex.OwnerOf(err)
+
+			require.False(t, ok)
+			require.Empty(t, owner)
+		}
+	})
+}
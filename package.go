@@ -19,6 +19,23 @@
 // - Use Error.Reason for simple text descriptions
 // - Use Error.Because when wrapping existing errors
 //
+// # Chain traversal
+//
+// Helpers walking the chain (Error, Flatten, ExposeAll, Breadcrumbs, Dump and others) share the same rules:
+// links are visited from the outermost down, a standard error terminates the chain,
+// and links with an empty (nil) identity are skipped as if their cause was attached directly.
+// The rendered output (Error, Flatten, JSON) also skips links with an empty message,
+// so it never contains a dangling Separator. Inside an ex chain, a fmt.Errorf("...: %w") wrapper
+// is descended through as a link showing its own prefix, see Adopt for converting a whole fmt.Errorf chain.
+//
+// # Immutability
+//
+// Errors are immutable values, safe to share across goroutines. Wrapping and attaching metadata
+// (WithField, WithAttempts, WithStack, Freeze and others) are copy-on-write: they return a new error
+// and never change anything reachable from the original one, so an error stored in a struct or logged
+// from several places can be wrapped, annotated and read concurrently. The package tests check this
+// property under the race detector.
+//
 // # Example
 //
 // This example demonstrates how to define domain-specific errors and wrap them
@@ -0,0 +1,57 @@
+package ex
+
+import (
+	"strings"
+)
+
+// Parse splits the flattened error message back into the chain of links.
+// Every link becomes an Error identity, the last one is the cause of the chain.
+// When escaping is turned on with SetEscapeSeparator, the "\\:" and "\\\\" sequences are unescaped,
+// so the messages are split unambiguously. Otherwise (the default) backslashes are kept as is
+// and every Separator is taken as a link boundary, so Parse(err.Error()).Error() is the same text.
+// If the text is empty, the result error will be nil.
+func Parse(text string) error {
+	if text == "" {
+		return nil
+	}
+
+	var (
+		parts   []string
+		builder strings.Builder
+		escaped = escapeSeparator.Load()
+	)
+
+	for i := 0; i < len(text); i++ {
+		switch {
+		case escaped && text[i] == '\\' && i+1 < len(text) && (text[i+1] == '\\' || text[i+1] == ':'):
+			i++
+
+			builder.WriteByte(text[i])
+		case strings.HasPrefix(text[i:], Separator):
+			i += len(Separator) - 1
+
+			parts = append(parts, builder.String())
+			builder.Reset()
+		default:
+			builder.WriteByte(text[i])
+		}
+	}
+
+	return build(append(parts, builder.String()))
+}
+
+// build creates the chain of Error identities from the link messages, the last one is the cause of the chain.
+// If there are no messages, the result error will be nil.
+func build(list []string) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	var err error = Error(list[len(list)-1])
+
+	for i := len(list) - 2; i >= 0; i-- {
+		err = &xError{error: Error(list[i]), cause: err, meta: nil, text: "", flags: 0}
+	}
+
+	return err
+}
@@ -0,0 +1,87 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty text", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.Parse(""))
+	})
+
+	t.Run("single link", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, ex.Error("user not found"), ex.Parse("user not found"))
+	})
+
+	t.Run("plain chain", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.Parse("user not found: database error: connection reset")
+
+		require.Equal(t, []string{"user not found", "database error", "connection reset"}, ex.Flatten(err))
+		require.ErrorIs(t, err, ex.Error("user not found"))
+		require.ErrorIs(t, err, ex.Error("database error"))
+		require.ErrorIs(t, err, ex.Error("connection reset"))
+	})
+
+	t.Run("backslashes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.Error("mount failed").Because(ex.Error(`C:\\share`).Because(errors.New(`regex \: x`)))
+
+		parsed := ex.Parse(err.Error())
+
+		require.Equal(t, []string{"mount failed", `C:\\share`, `regex \`, "x"}, ex.Flatten(parsed))
+		require.Equal(t, err.Error(), parsed.Error())
+		require.ErrorIs(t, parsed, ex.Error(`C:\\share`))
+	})
+}
+
+//nolint:paralleltest // changes the process-wide rendering options
+func TestSetEscapeSeparator(t *testing.T) {
+	const (
+		fetchErr = ex.Error("fetch: failed")
+		dialErr  = ex.Error(`dial\: tcp`)
+	)
+
+	var (
+		lookupErr = errors.New("dial tcp: lookup host: no such host")
+		err       = fetchErr.Because(dialErr.Because(lookupErr))
+	)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		require.EqualError(t, err, `fetch: failed: dial\: tcp: dial tcp: lookup host: no such host`)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		ex.SetEscapeSeparator(true)
+		t.Cleanup(func() { ex.SetEscapeSeparator(false) })
+
+		text := err.Error()
+
+		require.Equal(t, `fetch\: failed: dial\\\: tcp: dial tcp\: lookup host\: no such host`, text)
+
+		parsed := ex.Parse(text)
+
+		links := ex.ExposeAll(parsed)
+
+		require.Len(t, links, 3)
+		require.Equal(t, fetchErr, links[0].Identity)
+		require.Equal(t, dialErr, links[1].Identity)
+		require.Equal(t, ex.Error(lookupErr.Error()), links[2].Identity)
+		require.Equal(t, text, parsed.Error())
+		require.ErrorIs(t, parsed, fetchErr)
+		require.ErrorIs(t, parsed, dialErr)
+	})
+}
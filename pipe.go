@@ -0,0 +1,39 @@
+package ex
+
+// Step is a labeled step of the pipeline run by PipeLabeled.
+type Step struct {
+	Fn func() error // The step itself.
+	ID Error        // The identity of the step failure.
+}
+
+// Pipe runs the steps in order and stops at the first failure, returning its error untouched.
+// All remaining steps are skipped. Useful for replacing `if err != nil { return err }` sequences.
+func Pipe(steps ...func() error) error {
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PipeLabeled runs the steps in order and stops at the first failure, wrapping its error
+// with the identity of the failed step. All remaining steps are skipped.
+func PipeLabeled(steps ...Step) error {
+	for _, step := range steps {
+		if step.Fn == nil {
+			continue
+		}
+
+		if err := step.Fn(); err != nil {
+			return step.ID.Because(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestPipe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all succeed", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []int
+
+		err := ex.Pipe(
+			func() error { calls = append(calls, 1); return nil },
+			nil,
+			func() error { calls = append(calls, 2); return nil },
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, calls)
+	})
+
+	t.Run("stops at failure", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls   []int
+			stepErr = errors.New("step failed")
+		)
+
+		err := ex.Pipe(
+			func() error { calls = append(calls, 1); return nil },
+			func() error { calls = append(calls, 2); return stepErr },
+			func() error { calls = append(calls, 3); return nil },
+		)
+
+		require.Same(t, stepErr, err)
+		require.Equal(t, []int{1, 2}, calls)
+	})
+}
+
+func TestPipeLabeled(t *testing.T) {
+	t.Parallel()
+
+	const (
+		loadErr = ex.Error("load failed")
+		saveErr = ex.Error("save failed")
+	)
+
+	t.Run("all succeed", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.PipeLabeled(
+			ex.Step{ID: loadErr, Fn: func() error { return nil }},
+			ex.Step{ID: saveErr, Fn: nil},
+		)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("stops at failure", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls   []ex.Error
+			diskErr = errors.New("disk is full")
+		)
+
+		err := ex.PipeLabeled(
+			ex.Step{ID: loadErr, Fn: func() error { calls = append(calls, loadErr); return nil }},
+			ex.Step{ID: saveErr, Fn: func() error { calls = append(calls, saveErr); return diskErr }},
+			ex.Step{ID: loadErr, Fn: func() error { calls = append(calls, loadErr); return nil }},
+		)
+
+		require.EqualError(t, err, "save failed: disk is full")
+		require.ErrorIs(t, err, saveErr)
+		require.ErrorIs(t, err, diskErr)
+		require.NotErrorIs(t, err, loadErr)
+		require.Equal(t, []ex.Error{loadErr, saveErr}, calls)
+	})
+}
@@ -0,0 +1,33 @@
+package ex
+
+import "strconv"
+
+// positionKey is the metadata key of the source position.
+type positionKey struct{}
+
+// position is the location in a user source file the error refers to.
+type position struct {
+	file string
+	line int
+	col  int
+}
+
+// String returns the position in the conventional "file:line:col" form.
+func (p position) String() string {
+	return p.file + ":" + strconv.Itoa(p.line) + ":" + strconv.Itoa(p.col)
+}
+
+// At creates a new xError, using the current Error as the root and attaching the position in the source file
+// the error refers to, e.g. a syntax error reported by a compiler or linter built on the package.
+// Unlike the stack (the Go code location) it points into the processed user files.
+// The position is shown by Dump (and %+v), but never by the Error message.
+func (c Error) At(file string, line, col int) error {
+	return attach(c, positionKey{}, position{file: file, line: line, col: col})
+}
+
+// PositionOf returns the source position of the nearest link of the error chain created with At.
+func PositionOf(err error) (string, int, int, bool) {
+	pos, ok := lookup[position](err, positionKey{})
+
+	return pos.file, pos.line, pos.col, ok
+}
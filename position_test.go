@@ -0,0 +1,52 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestPositionOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		syntaxErr = ex.Error("syntax error")
+		lintErr   = ex.Error("lint failed")
+	)
+
+	t.Run("survives wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = syntaxErr.At("config/app.yaml", 12, 7)
+			err   = lintErr.Because(inner)
+		)
+
+		file, line, col, ok := ex.PositionOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, "config/app.yaml", file)
+		require.Equal(t, 12, line)
+		require.Equal(t, 7, col)
+		require.ErrorIs(t, err, syntaxErr)
+		require.EqualError(t, err, "lint failed: syntax error")
+		require.Equal(t, "lint failed\nsyntax error\n    position: config/app.yaml:12:7", fmt.Sprintf("%+v", err))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), syntaxErr.Reason("unexpected token")} {
+			file, line, col, ok := ex.PositionOf(err)
+
+			require.False(t, ok)
+			require.Empty(t, file)
+			require.Zero(t, line)
+			require.Zero(t, col)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package ex
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// registry holds the metadata registered against sentinel identities.
+//
+//nolint:gochecknoglobals // the registry is process-wide by design
+var registry struct {
+	entries map[Error]*meta
+	mu      sync.RWMutex
+}
+
+// ErrorInfo describes the metadata registered against an identity.
+// Metadata that is not registered has the zero value.
+type ErrorInfo struct {
+	Identity   Error    // The registered identity.
+	Help       string   // The help text registered with RegisterHelp.
+	Hint       string   // The remediation hint registered with RegisterHint.
+	DocsURL    string   // The documentation URL registered with RegisterDocsURL.
+	Owner      string   // The owner registered with RegisterOwner.
+	Code       int      // The code registered with RegisterCode.
+	HTTPStatus int      // The HTTP status registered with RegisterHTTPStatus.
+	Severity   Severity // The severity registered with RegisterSeverity.
+	Retryable  bool     // Whether the identity is registered with RegisterRetryable.
+	Deprecated bool     // Whether the identity is registered with RegisterDeprecated.
+}
+
+// Registry returns a snapshot of every identity with registered metadata, sorted by the identity.
+// This enables generating an error reference page or validating that every identity has the required metadata.
+// The snapshot is a copy, safe to read and change without affecting the registry.
+func Registry() []ErrorInfo {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	infos := make([]ErrorInfo, 0, len(registry.entries))
+
+	for identity, entry := range registry.entries {
+		info := ErrorInfo{
+			Identity:   identity,
+			Help:       "",
+			Hint:       "",
+			DocsURL:    "",
+			Owner:      "",
+			Code:       0,
+			HTTPStatus: 0,
+			Severity:   0,
+			Retryable:  false,
+			Deprecated: false,
+		}
+
+		// the most recently registered value of the key is the first one seen
+		seen := make(map[any]bool)
+
+		entry.each(func(key, value any) {
+			if seen[key] {
+				return
+			}
+
+			seen[key] = true
+
+			switch key.(type) {
+			case helpKey:
+				info.Help, _ = value.(string)
+			case hintKey:
+				info.Hint, _ = value.(string)
+			case docsKey:
+				info.DocsURL, _ = value.(string)
+			case ownerKey:
+				info.Owner, _ = value.(string)
+			case codeKey:
+				info.Code, _ = value.(int)
+			case statusKey:
+				info.HTTPStatus, _ = value.(int)
+			case severityKey:
+				info.Severity, _ = value.(Severity)
+			case retryableKey:
+				info.Retryable, _ = value.(bool)
+			case deprecatedKey:
+				info.Deprecated, _ = value.(bool)
+			}
+		})
+
+		infos = append(infos, info)
+	}
+
+	slices.SortFunc(infos, func(a, b ErrorInfo) int {
+		return cmp.Compare(a.Identity, b.Identity)
+	})
+
+	return infos
+}
+
+// register attaches the key/value pair to the identity, so every link of a chain with the identity inherits it.
+func register(identity Error, key, value any) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if registry.entries == nil {
+		registry.entries = make(map[Error]*meta)
+	}
+
+	registry.entries[identity] = registry.entries[identity].with(key, value)
+}
+
+// registered returns the value of the key registered against the identity.
+func registered(identity error, key any) (any, bool) {
+	return entry(identity).find(key)
+}
+
+// entry returns the metadata registered against the identity.
+func entry(identity error) *meta {
+	var sentinel Error
+	if identity == nil || !errors.As(identity, &sentinel) {
+		return nil
+	}
+
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return registry.entries[sentinel]
+}
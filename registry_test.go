@@ -0,0 +1,76 @@
+package ex_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	const (
+		notFoundErr = ex.Error("registry test: not found")
+		limitErr    = ex.Error("registry test: rate limited")
+	)
+
+	ex.RegisterCode(notFoundErr, 404)
+	ex.RegisterHelp(notFoundErr, "check the id")
+	ex.RegisterDocsURL(notFoundErr, "https://example.com/errors/not-found")
+	ex.RegisterOwner(notFoundErr, "users-team")
+	ex.RegisterSeverity(limitErr, ex.SeverityWarn)
+	ex.RegisterHint(limitErr, "retry after a minute")
+	ex.RegisterCode(limitErr, 400)
+	ex.RegisterCode(limitErr, 429)
+	ex.RegisterHTTPStatus(limitErr, 429)
+	ex.RegisterRetryable(limitErr)
+	ex.RegisterDeprecated(notFoundErr)
+
+	snapshot := func() []ex.ErrorInfo {
+		var infos []ex.ErrorInfo
+
+		for _, info := range ex.Registry() {
+			if strings.HasPrefix(string(info.Identity), "registry test: ") {
+				infos = append(infos, info)
+			}
+		}
+
+		return infos
+	}
+
+	got := snapshot()
+
+	require.Equal(t, []ex.ErrorInfo{
+		{
+			Identity:   notFoundErr,
+			Help:       "check the id",
+			Hint:       "",
+			DocsURL:    "https://example.com/errors/not-found",
+			Owner:      "users-team",
+			Code:       404,
+			HTTPStatus: 0,
+			Severity:   0,
+			Retryable:  false,
+			Deprecated: true,
+		},
+		{
+			Identity:   limitErr,
+			Help:       "",
+			Hint:       "retry after a minute",
+			DocsURL:    "",
+			Owner:      "",
+			Code:       429,
+			HTTPStatus: 429,
+			Severity:   ex.SeverityWarn,
+			Retryable:  true,
+			Deprecated: false,
+		},
+	}, got)
+
+	got[0].Code = 500
+
+	require.Equal(t, 404, snapshot()[0].Code)
+}
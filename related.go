@@ -0,0 +1,21 @@
+package ex
+
+// relatedKey is the metadata key of the related parent error.
+type relatedKey struct{}
+
+// RelatedTo creates a new xError, using the current Error as the root and keeping a reference
+// to the parent error, e.g. "this retry failed, related to the original request error".
+// The parent is a sibling link, not a cause: it never appears in the Error message and errors.Is doesn't match it.
+// If the parent is nil, no reference is kept.
+func (c Error) RelatedTo(parent error) error {
+	if parent == nil {
+		return c.Because(nil)
+	}
+
+	return attach(c, relatedKey{}, parent)
+}
+
+// RelatedOf returns the parent error referenced by the nearest link of the error chain that has one.
+func RelatedOf(err error) (error, bool) {
+	return lookup[error](err, relatedKey{})
+}
@@ -0,0 +1,65 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestRelatedOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		retryErr   = ex.Error("related test: retry failed")
+		requestErr = ex.Error("related test: request failed")
+		workerErr  = ex.Error("related test: worker failed")
+	)
+
+	t.Run("related parent", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			parent = requestErr.Reason("timeout")
+			err    = ex.Conv(retryErr.RelatedTo(parent)).Reason("connection refused")
+		)
+
+		related, ok := ex.RelatedOf(err)
+
+		require.True(t, ok)
+		require.Same(t, parent, related)
+		require.EqualError(t, err, "related test: retry failed: connection refused")
+		require.ErrorIs(t, err, retryErr)
+		require.NotErrorIs(t, err, requestErr)
+		require.Equal(t, ""+
+			"related test: retry failed\n"+
+			"    related: related test: request failed: timeout\n"+
+			"connection refused", ex.Dump(err))
+	})
+
+	t.Run("survives wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		parent := errors.New("standard")
+
+		related, ok := ex.RelatedOf(workerErr.Because(retryErr.RelatedTo(parent)))
+
+		require.True(t, ok)
+		require.Same(t, parent, related)
+	})
+
+	t.Run("no parent", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), retryErr, retryErr.RelatedTo(nil)} {
+			related, ok := ex.RelatedOf(err)
+
+			require.False(t, ok)
+			require.NoError(t, related)
+		}
+
+		require.EqualError(t, retryErr.RelatedTo(nil), "related test: retry failed")
+	})
+}
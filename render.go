@@ -0,0 +1,133 @@
+package ex
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// maxMessageLen is the limit set with SetMaxMessageLen.
+//
+//nolint:gochecknoglobals // rendering options are process-wide by design
+var maxMessageLen atomic.Int64
+
+// rawControl reports whether escaping is turned off with SetEscapeControl.
+//
+//nolint:gochecknoglobals // rendering options are process-wide by design
+var rawControl atomic.Bool
+
+// controlEscaper replaces the control characters breaking line-oriented logs with their escape sequences.
+//
+//nolint:gochecknoglobals // stateless and safe for concurrent use
+var controlEscaper = strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// escapeSeparator reports whether escaping is turned on with SetEscapeSeparator.
+//
+//nolint:gochecknoglobals // rendering options are process-wide by design
+var escapeSeparator atomic.Bool
+
+// separatorEscaper backslash-escapes the separator and the backslash itself.
+//
+//nolint:gochecknoglobals // stateless and safe for concurrent use
+var separatorEscaper = strings.NewReplacer(`\`, `\\`, Separator, `\`+Separator)
+
+// renderHook holds the function set with SetRenderHook.
+//
+//nolint:gochecknoglobals // rendering options are process-wide by design
+var renderHook atomic.Pointer[func(err error) error]
+
+// SetRenderHook sets the function transforming the error chain right before it is rendered by the Error method,
+// Flatten, Dump, JSON encoding and the other rendering helpers, e.g. to redact or localize it in one place.
+// The stored chain is never changed, so Expose, errors.Is and the metadata helpers still see the original.
+// A nil function (default) unsets the hook, and so does a nil result for that error.
+// The hook gets a copy of the error that renders without the hook, so the hook can call its Error method.
+// The hook must not return a foreign wrapper around an ex chain, as rendering it would run the hook again.
+func SetRenderHook(fn func(err error) error) {
+	if fn == nil {
+		renderHook.Store(nil)
+
+		return
+	}
+
+	renderHook.Store(&fn)
+}
+
+// hooked transforms the error with the hook set with SetRenderHook.
+// The copy of the error passed to the hook is marked, so rendering it in the hook doesn't run the hook again.
+func hooked(err error) error {
+	hook := renderHook.Load()
+	if hook == nil || err == nil {
+		return err
+	}
+
+	arg := err
+	if xer, ok := err.(*xError); ok {
+		if xer.flags&flagHooked != 0 {
+			return err
+		}
+
+		clone := *xer
+		clone.flags |= flagHooked
+		arg = &clone
+	}
+
+	if transformed := (*hook)(arg); transformed != nil {
+		return transformed
+	}
+
+	return err
+}
+
+// SetEscapeSeparator enables or disables (default) backslash-escaping of the Separator (and the backslash itself)
+// inside link messages rendered by the Error method and Flatten. With escaping enabled a message like
+// "dial tcp: lookup host" renders as "dial tcp\: lookup host", so the flattened string can be split
+// back into links unambiguously by Parse.
+func SetEscapeSeparator(enabled bool) {
+	escapeSeparator.Store(enabled)
+}
+
+// SetEscapeControl enables (default) or disables escaping of newlines, carriage returns and tabs
+// in link messages rendered by the Error method and Flatten, so the error stays on a single line.
+// The block of Error.ReasonBlock is the only exception: it keeps its newlines by design.
+func SetEscapeControl(enabled bool) {
+	rawControl.Store(!enabled)
+}
+
+// SetMaxMessageLen limits the length of every link message (in bytes) when the chain is rendered
+// by the Error method, Flatten or JSON encoding. Longer messages are cut and marked with an ellipsis
+// and the number of omitted bytes. The stored errors are never changed.
+// Zero or negative value means unlimited (default).
+func SetMaxMessageLen(n int) {
+	maxMessageLen.Store(int64(max(n, 0)))
+}
+
+// render prepares the link message for the single-line output according to the rendering options.
+func render(message string) string {
+	message = truncate(message)
+
+	if !rawControl.Load() && strings.ContainsAny(message, "\n\r\t") {
+		message = controlEscaper.Replace(message)
+	}
+
+	if escapeSeparator.Load() {
+		message = separatorEscaper.Replace(message)
+	}
+
+	return message
+}
+
+// truncate cuts the link message according to the limit set with SetMaxMessageLen.
+func truncate(message string) string {
+	limit := int(maxMessageLen.Load())
+	if limit == 0 || len(message) <= limit {
+		return message
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+
+	return message[:cut] + "…(" + strconv.Itoa(len(message)-cut) + " more bytes)"
+}
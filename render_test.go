@@ -0,0 +1,181 @@
+package ex_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+//nolint:paralleltest // changes the process-wide rendering options
+func TestSetMaxMessageLen(t *testing.T) {
+	const queryErr = ex.Error("query failed")
+
+	var (
+		statement = strings.Repeat("x", 100*1024)
+		causeErr  = errors.New(statement)
+		err       = queryErr.Because(causeErr)
+	)
+
+	ex.SetMaxMessageLen(10)
+	t.Cleanup(func() { ex.SetMaxMessageLen(0) })
+
+	t.Run("bounded output", func(t *testing.T) {
+		want := "query fail…(2 more bytes): xxxxxxxxxx…(102390 more bytes)"
+
+		require.EqualError(t, err, want)
+		require.Equal(t, want, strings.Join(ex.Flatten(err), ex.Separator))
+
+		data, jsonErr := json.Marshal(err)
+
+		require.NoError(t, jsonErr)
+		require.Less(t, len(data), 200)
+	})
+
+	t.Run("untouched originals", func(t *testing.T) {
+		require.Equal(t, statement, causeErr.Error())
+		require.Equal(t, "query failed", queryErr.Error())
+
+		_, cause := ex.Expose(err)
+
+		require.Same(t, causeErr, cause)
+	})
+
+	t.Run("multibyte runes", func(t *testing.T) {
+		err := ex.Error("ошибка").Because(errors.New("ошибка"))
+
+		require.EqualError(t, err, "ошибк…(2 more bytes): ошибк…(2 more bytes)")
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		ex.SetMaxMessageLen(0)
+
+		require.EqualError(t, err, "query failed: "+statement)
+	})
+}
+
+//nolint:paralleltest // changes the process-wide rendering options
+func TestSetEscapeControl(t *testing.T) {
+	const decodeErr = ex.Error("decode failed")
+
+	var (
+		causeErr = errors.New("invalid character\n\tat line 1\r")
+		err      = decodeErr.Because(causeErr)
+	)
+
+	t.Run("escaped by default", func(t *testing.T) {
+		require.EqualError(t, err, `decode failed: invalid character\n\tat line 1\r`)
+		require.Equal(t, []string{"decode failed", `invalid character\n\tat line 1\r`}, ex.Flatten(err))
+		require.EqualError(t, decodeErr.Reason("plain text"), "decode failed: plain text")
+
+		data, jsonErr := json.Marshal(err)
+
+		require.NoError(t, jsonErr)
+		require.JSONEq(
+			t,
+			`{"error":"decode failed","cause":{"error":"invalid character\n\tat line 1\r"}}`,
+			string(data),
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ex.SetEscapeControl(false)
+		t.Cleanup(func() { ex.SetEscapeControl(true) })
+
+		require.EqualError(t, err, "decode failed: invalid character\n\tat line 1\r")
+	})
+}
+
+//nolint:paralleltest // sets the process-wide render hook
+func TestSetRenderHook(t *testing.T) {
+	const (
+		loginErr  = ex.Error("login failed")
+		secretErr = ex.Error("bad password")
+	)
+
+	var (
+		causeErr = secretErr.Reason("hunter2")
+		err      = loginErr.Because(causeErr)
+	)
+
+	ex.SetRenderHook(func(err error) error {
+		if !errors.Is(err, secretErr) {
+			return nil
+		}
+
+		return loginErr.Reason("[redacted]")
+	})
+	t.Cleanup(func() { ex.SetRenderHook(nil) })
+
+	t.Run("rendered", func(t *testing.T) {
+		require.EqualError(t, err, "login failed: [redacted]")
+		require.Equal(t, []string{"login failed", "[redacted]"}, ex.Flatten(err))
+		require.Equal(t, "login failed\n[redacted]", ex.Dump(err))
+
+		data, jsonErr := json.Marshal(err)
+
+		require.NoError(t, jsonErr)
+		require.JSONEq(t, `{"error":"login failed","cause":{"error":"[redacted]"}}`, string(data))
+	})
+
+	t.Run("untouched originals", func(t *testing.T) {
+		_, cause := ex.Expose(err)
+
+		require.Same(t, causeErr, cause)
+		require.ErrorIs(t, err, secretErr)
+	})
+
+	t.Run("not transformed", func(t *testing.T) {
+		require.EqualError(t, loginErr.Reason("locked"), "login failed: locked")
+	})
+
+	t.Run("cause string", func(t *testing.T) {
+		require.Equal(t, "[redacted]", ex.CauseString(err))
+	})
+
+	t.Run("expose all", func(t *testing.T) {
+		require.Equal(t, []ex.Link{
+			{Identity: loginErr, Cause: ex.Error("[redacted]"), Foreign: false},
+			{Identity: ex.Error("[redacted]"), Cause: nil, Foreign: true},
+		}, ex.ExposeAll(err))
+	})
+
+	t.Run("json lines", func(t *testing.T) {
+		require.Equal(t, ""+
+			`{"identity":"login failed","depth":0,"terminal":false}`+"\n"+
+			`{"identity":"[redacted]","depth":1,"terminal":true}`+"\n", string(ex.JSONLines(err)))
+	})
+
+	t.Run("breadcrumbs", func(t *testing.T) {
+		require.Equal(t, []ex.Breadcrumb{
+			{Identity: "login failed", Code: 0},
+			{Identity: "[redacted]", Code: 0},
+		}, ex.Breadcrumbs(err))
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		ex.SetRenderHook(nil)
+
+		require.EqualError(t, err, "login failed: bad password: hunter2")
+	})
+
+	t.Run("rendering in the hook", func(t *testing.T) {
+		ex.SetRenderHook(func(err error) error {
+			message := err.Error()
+			if !strings.Contains(message, "hunter2") {
+				return nil
+			}
+
+			return ex.Error(strings.ReplaceAll(message, "hunter2", "***"))
+		})
+
+		require.EqualError(t, err, "login failed: bad password: ***")
+		require.Equal(t, []string{"login failed: bad password: ***"}, ex.Flatten(err))
+		require.Equal(t, "login failed: bad password: ***", ex.Dump(err))
+		require.EqualError(t, loginErr.Reason("locked"), "login failed: locked")
+	})
+}
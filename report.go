@@ -0,0 +1,257 @@
+package ex
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Reporter is a sink of serious errors, e.g. an adapter of an error tracker, a chat webhook or a log fallback.
+type Reporter interface {
+	// Report sends the error to the sink.
+	Report(ctx context.Context, err error)
+}
+
+// ReporterFunc adapts an ordinary function to the Reporter interface.
+type ReporterFunc func(ctx context.Context, err error)
+
+// Report calls the function with the context and the error.
+func (f ReporterFunc) Report(ctx context.Context, err error) {
+	f(ctx, err)
+}
+
+// reporting holds the reporters added with AddReporter and the configuration of Report.
+//
+//nolint:gochecknoglobals // reporters are process-wide by design
+var reporting struct {
+	reporters atomic.Pointer[[]*Reporter]
+	queue     *reportQueue
+	dropped   atomic.Uint64
+	panicked  atomic.Uint64
+	mu        sync.RWMutex
+	level     Severity
+}
+
+// reportQueue is the asynchronous queue of Report. The jobs channel is never closed, so it can be sent to
+// without the lock: SetReportQueue closes done instead, and drain closes drained after the last job.
+type reportQueue struct {
+	jobs    chan report
+	done    chan struct{}
+	drained chan struct{}
+}
+
+// report is a job of the asynchronous queue: the error to report, or the flush marker to close.
+type report struct {
+	ctx     context.Context //nolint:containedctx // the job carries the context of the Report call
+	err     error
+	flushed chan struct{}
+}
+
+// AddReporter adds the reporter to the sinks Report forwards errors to. Nil reporters are ignored.
+// The returned function removes the reporter, e.g. in test cleanup.
+func AddReporter(reporter Reporter) func() {
+	if reporter == nil {
+		return func() {}
+	}
+
+	reporting.mu.Lock()
+	defer reporting.mu.Unlock()
+
+	added := &reporter
+
+	var reporters []*Reporter
+	if current := reporting.reporters.Load(); current != nil {
+		reporters = append(reporters, *current...)
+	}
+
+	reporters = append(reporters, added)
+	reporting.reporters.Store(&reporters)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			reporting.mu.Lock()
+			defer reporting.mu.Unlock()
+
+			var rest []*Reporter
+
+			for _, current := range *reporting.reporters.Load() {
+				if current != added {
+					rest = append(rest, current)
+				}
+			}
+
+			reporting.reporters.Store(&rest)
+		})
+	}
+}
+
+// SetReportLevel sets the minimal severity of the errors forwarded by Report,
+// the zero value means SeverityWarn (default).
+// Errors without a severity (see SeverityOf) are treated as SeverityError.
+func SetReportLevel(level Severity) {
+	reporting.mu.Lock()
+	defer reporting.mu.Unlock()
+
+	reporting.level = level
+}
+
+// SetReportQueue switches Report to the asynchronous mode with a bounded queue of the size, so the call sites
+// never wait for the reporters. When the queue is full, the error is dropped and counted by ReportsDropped.
+// A not positive size switches back to the synchronous (default) mode. The errors already queued are still
+// reported in the background, use FlushReporters to wait for them.
+func SetReportQueue(size int) {
+	reporting.mu.Lock()
+	defer reporting.mu.Unlock()
+
+	if reporting.queue != nil {
+		close(reporting.queue.done)
+		reporting.queue = nil
+	}
+
+	if size > 0 {
+		reporting.queue = &reportQueue{
+			jobs:    make(chan report, size),
+			done:    make(chan struct{}),
+			drained: make(chan struct{}),
+		}
+
+		go drain(reporting.queue)
+	}
+}
+
+// Report forwards the error to every reporter added with AddReporter, if its severity is not below
+// the one set with SetReportLevel. A panicking reporter doesn't affect the others nor the caller,
+// its panics are counted by ReportsPanicked. The reporters may configure the reporting themselves.
+// In the asynchronous mode (see SetReportQueue) the reporters get a context that is never canceled,
+// but keeps the values of ctx. If the error is nil, nothing is reported.
+func Report(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	severity, ok := SeverityOf(err)
+	if !ok {
+		severity = SeverityError
+	}
+
+	reporting.mu.RLock()
+
+	level := reporting.level
+	if level == 0 {
+		level = SeverityWarn
+	}
+
+	// the job is queued under the lock, so it can't land in a queue stopped by SetReportQueue meanwhile
+	queued := reporting.queue != nil
+	if queued && severity >= level {
+		select {
+		case reporting.queue.jobs <- report{ctx: context.WithoutCancel(ctx), err: err, flushed: nil}:
+		default:
+			reporting.dropped.Add(1)
+		}
+	}
+
+	reporting.mu.RUnlock()
+
+	// the reporters run without the lock, so they can add reporters or change the configuration
+	if !queued && severity >= level {
+		fanOut(ctx, err)
+	}
+}
+
+// FlushReporters waits until the errors queued in the asynchronous mode are reported, or the context is done.
+// In the synchronous mode it returns immediately. It doesn't hold the lock while waiting, so the reporters
+// may configure the reporting meanwhile.
+func FlushReporters(ctx context.Context) error {
+	flushed := make(chan struct{})
+
+	reporting.mu.RLock()
+	queue := reporting.queue
+	reporting.mu.RUnlock()
+
+	if queue == nil {
+		return nil
+	}
+
+	// a queue stopped by SetReportQueue meanwhile is flushed once it is drained
+	select {
+	case queue.jobs <- report{ctx: nil, err: nil, flushed: flushed}:
+	case <-queue.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-queue.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReportsDropped returns the number of errors dropped by Report because the queue was full.
+func ReportsDropped() uint64 {
+	return reporting.dropped.Load()
+}
+
+// ReportsPanicked returns the number of panics recovered from the reporters by Report.
+func ReportsPanicked() uint64 {
+	return reporting.panicked.Load()
+}
+
+// drain reports the queued errors until the queue is stopped, and then the errors left in it.
+func drain(queue *reportQueue) {
+	defer close(queue.drained)
+
+	for {
+		select {
+		case job := <-queue.jobs:
+			job.run()
+		case <-queue.done:
+			for {
+				select {
+				case job := <-queue.jobs:
+					job.run()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// run reports the error of the job, or closes the flush marker.
+func (r report) run() {
+	if r.flushed != nil {
+		close(r.flushed)
+
+		return
+	}
+
+	fanOut(r.ctx, r.err)
+}
+
+// fanOut calls every reporter with the error, isolating the panics of each one.
+func fanOut(ctx context.Context, err error) {
+	reporters := reporting.reporters.Load()
+	if reporters == nil {
+		return
+	}
+
+	for _, reporter := range *reporters {
+		func() {
+			defer func() {
+				if recover() != nil {
+					reporting.panicked.Add(1)
+				}
+			}()
+
+			(*reporter).Report(ctx, err)
+		}()
+	}
+}
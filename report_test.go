@@ -0,0 +1,224 @@
+package ex_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+// recorder is a fake reporter recording the reported errors matching its identity.
+type recorder struct {
+	identity ex.Error
+	reported []string
+	mu       sync.Mutex
+}
+
+func (r *recorder) Report(_ context.Context, err error) {
+	if !errors.Is(err, r.identity) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reported = append(r.reported, err.Error())
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reported
+}
+
+//nolint:paralleltest // adds process-wide reporters
+func TestReport(t *testing.T) {
+	const reportErr = ex.Error("report error")
+
+	var (
+		first    = &recorder{identity: reportErr, reported: nil, mu: sync.Mutex{}}
+		second   = &recorder{identity: reportErr, reported: nil, mu: sync.Mutex{}}
+		panicked = ex.ReportsPanicked()
+	)
+
+	t.Cleanup(ex.AddReporter(first))
+	t.Cleanup(ex.AddReporter(nil))
+	t.Cleanup(ex.AddReporter(ex.ReporterFunc(func(_ context.Context, err error) {
+		if errors.Is(err, reportErr) {
+			panic("broken reporter")
+		}
+	})))
+	t.Cleanup(ex.AddReporter(second))
+
+	t.Run("fan-out", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			ex.Report(t.Context(), reportErr.Reason("no severity"))
+			ex.Report(t.Context(), ex.WithSeverity(reportErr.Reason("warn"), ex.SeverityWarn))
+			ex.Report(t.Context(), ex.WithSeverity(reportErr.Reason("info"), ex.SeverityInfo))
+			ex.Report(t.Context(), nil)
+		})
+
+		want := []string{"report error: no severity", "report error: warn"}
+
+		require.Equal(t, want, first.list())
+		require.Equal(t, want, second.list())
+		require.Equal(t, panicked+2, ex.ReportsPanicked())
+	})
+
+	t.Run("level", func(t *testing.T) {
+		ex.SetReportLevel(ex.SeverityFatal)
+		defer ex.SetReportLevel(0)
+
+		ex.Report(t.Context(), ex.WithSeverity(reportErr.Reason("error"), ex.SeverityError))
+		ex.Report(t.Context(), ex.WithSeverity(reportErr.Reason("fatal"), ex.SeverityFatal))
+
+		require.Equal(t, "report error: fatal", first.list()[len(first.list())-1])
+		require.Len(t, first.list(), 3)
+	})
+
+	t.Run("synchronous flush", func(t *testing.T) {
+		require.NoError(t, ex.FlushReporters(t.Context()))
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		third := &recorder{identity: reportErr, reported: nil, mu: sync.Mutex{}}
+
+		remove := ex.AddReporter(third)
+		ex.Report(t.Context(), reportErr.Reason("added"))
+
+		remove()
+		remove()
+		ex.Report(t.Context(), reportErr.Reason("removed"))
+
+		require.Equal(t, []string{"report error: added"}, third.list())
+		require.Equal(t, "report error: removed", first.list()[len(first.list())-1])
+	})
+
+	t.Run("reentrant", func(t *testing.T) {
+		const reentrantErr = ex.Error("reentrant error")
+
+		nested := &recorder{identity: reentrantErr, reported: nil, mu: sync.Mutex{}}
+
+		t.Cleanup(ex.AddReporter(ex.ReporterFunc(func(_ context.Context, err error) {
+			if !errors.Is(err, reentrantErr) {
+				return
+			}
+
+			// the reporter configures the reporting without deadlocking Report
+			ex.SetReportLevel(0)
+			t.Cleanup(ex.AddReporter(nested))
+		})))
+
+		ex.Report(t.Context(), reentrantErr.Reason("first"))
+		ex.Report(t.Context(), reentrantErr.Reason("second"))
+
+		require.Equal(t, []string{"reentrant error: second"}, nested.list())
+	})
+}
+
+//nolint:paralleltest // switches the process-wide report queue
+func TestSetReportQueue(t *testing.T) {
+	const queueErr = ex.Error("queue error")
+
+	var (
+		started = make(chan struct{})
+		gate    = make(chan struct{})
+		once    sync.Once
+		done    = &recorder{identity: queueErr, reported: nil, mu: sync.Mutex{}}
+		dropped = ex.ReportsDropped()
+	)
+
+	t.Cleanup(ex.AddReporter(ex.ReporterFunc(func(ctx context.Context, err error) {
+		if !errors.Is(err, queueErr) {
+			return
+		}
+
+		once.Do(func() { close(started) })
+
+		<-gate
+
+		done.Report(ctx, err)
+	})))
+
+	ex.SetReportQueue(1)
+	defer ex.SetReportQueue(0)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	// the canceled context of the call doesn't cancel the background reporting
+	ex.Report(ctx, queueErr.Reason("first"))
+	<-started
+
+	ex.Report(t.Context(), queueErr.Reason("second"))
+	ex.Report(t.Context(), queueErr.Reason("overflow"))
+
+	require.Equal(t, dropped+1, ex.ReportsDropped())
+
+	timeout, stop := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer stop()
+
+	require.ErrorIs(t, ex.FlushReporters(timeout), context.DeadlineExceeded)
+
+	close(gate)
+
+	require.NoError(t, ex.FlushReporters(t.Context()))
+	require.Equal(t, []string{"queue error: first", "queue error: second"}, done.list())
+}
+
+//nolint:paralleltest // switches the process-wide report queue
+func TestFlushReporters(t *testing.T) {
+	const flushErr = ex.Error("flush error")
+
+	var (
+		started = make(chan struct{})
+		gate    = make(chan struct{})
+		once    sync.Once
+		done    = &recorder{identity: flushErr, reported: nil, mu: sync.Mutex{}}
+	)
+
+	t.Cleanup(ex.AddReporter(ex.ReporterFunc(func(ctx context.Context, err error) {
+		if !errors.Is(err, flushErr) {
+			return
+		}
+
+		once.Do(func() { close(started) })
+
+		<-gate
+
+		// the reporter configures the reporting while FlushReporters waits for the full queue
+		ex.SetReportLevel(0)
+
+		done.Report(ctx, err)
+	})))
+
+	ex.SetReportQueue(1)
+	defer ex.SetReportQueue(0)
+
+	ex.Report(t.Context(), flushErr.Reason("first"))
+	<-started
+
+	ex.Report(t.Context(), flushErr.Reason("second"))
+
+	flushed := make(chan error)
+
+	go func() { flushed <- ex.FlushReporters(t.Context()) }()
+
+	time.Sleep(10 * time.Millisecond)
+	close(gate)
+
+	select {
+	case err := <-flushed:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "FlushReporters deadlocked")
+	}
+
+	require.Equal(t, []string{"flush error: first", "flush error: second"}, done.list())
+}
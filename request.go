@@ -0,0 +1,40 @@
+package ex
+
+// requestKey is the metadata key of the request that caused the error.
+type requestKey struct{}
+
+// request is the request that caused the error.
+type request struct {
+	method string
+	path   string
+	id     string
+}
+
+// WithRequest creates a new xError, using the current Error as the root and attaching
+// the method and path of the request that caused it, e.g. "GET /users/42".
+// Only these strings are kept, so no large request objects are retained by the error.
+func (c Error) WithRequest(method, path string) error {
+	return attach(c, requestKey{}, request{method: method, path: path, id: ""})
+}
+
+// WithRequest attaches the method, path and ID of the request that caused the error
+// to the outermost link of the error, standardizing the context captured at the HTTP boundary.
+// The request survives further wrapping and can be read with RequestInfo.
+func WithRequest(err error, method, path, requestID string) error {
+	return attach(err, requestKey{}, request{method: method, path: path, id: requestID})
+}
+
+// RequestOf returns the method and path of the request attached to the nearest level of the error chain.
+func RequestOf(err error) (string, string, bool) {
+	req, ok := lookup[request](err, requestKey{})
+
+	return req.method, req.path, ok
+}
+
+// RequestInfo returns the method, path and ID of the request attached to the nearest level of the error chain.
+// The ID is empty when the request was attached without one, like Error.WithRequest does.
+func RequestInfo(err error) (string, string, string, bool) {
+	req, ok := lookup[request](err, requestKey{})
+
+	return req.method, req.path, req.id, ok
+}
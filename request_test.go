@@ -0,0 +1,127 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestRequestOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		handlerErr = ex.Error("handler failed")
+		userErr    = ex.Error("user not found")
+	)
+
+	t.Run("attached request", func(t *testing.T) {
+		t.Parallel()
+
+		err := handlerErr.WithRequest("GET", "/users/42")
+
+		method, path, ok := ex.RequestOf(err)
+
+		require.True(t, ok)
+		require.Equal(t, "GET", method)
+		require.Equal(t, "/users/42", path)
+		require.EqualError(t, err, "handler failed")
+		require.ErrorIs(t, err, handlerErr)
+	})
+
+	t.Run("survives wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = ex.Conv(userErr.WithRequest("GET", "/users/42")).Because(errors.New("no rows"))
+			outer = ex.Conv(handlerErr.WithRequest("POST", "/users")).Because(inner)
+		)
+
+		method, path, ok := ex.RequestOf(handlerErr.Because(inner))
+
+		require.True(t, ok)
+		require.Equal(t, "GET", method)
+		require.Equal(t, "/users/42", path)
+
+		method, path, ok = ex.RequestOf(outer)
+
+		require.True(t, ok)
+		require.Equal(t, "POST", method)
+		require.Equal(t, "/users", path)
+	})
+
+	t.Run("no request", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), userErr.Reason("no rows")} {
+			method, path, ok := ex.RequestOf(err)
+
+			require.False(t, ok)
+			require.Empty(t, method)
+			require.Empty(t, path)
+		}
+	})
+}
+
+func TestRequestInfo(t *testing.T) {
+	t.Parallel()
+
+	const (
+		handlerErr = ex.Error("handler failed")
+		userErr    = ex.Error("user not found")
+	)
+
+	t.Run("survives wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.WithRequest(userErr.Reason("no rows"), "GET", "/users/42", "req-1")
+		err = handlerErr.Because(ex.Conv(err).Because(errors.New("timeout")))
+
+		method, path, id, ok := ex.RequestInfo(err)
+
+		require.True(t, ok)
+		require.Equal(t, "GET", method)
+		require.Equal(t, "/users/42", path)
+		require.Equal(t, "req-1", id)
+		require.EqualError(t, err, "handler failed: user not found: timeout")
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.WithRequest(errors.New("standard"), "POST", "/users", "req-2")
+
+		method, path, id, ok := ex.RequestInfo(err)
+
+		require.True(t, ok)
+		require.Equal(t, "POST", method)
+		require.Equal(t, "/users", path)
+		require.Equal(t, "req-2", id)
+		require.EqualError(t, err, "standard")
+	})
+
+	t.Run("without id", func(t *testing.T) {
+		t.Parallel()
+
+		method, path, id, ok := ex.RequestInfo(handlerErr.WithRequest("GET", "/"))
+
+		require.True(t, ok)
+		require.Equal(t, "GET", method)
+		require.Equal(t, "/", path)
+		require.Empty(t, id)
+	})
+
+	t.Run("no request", func(t *testing.T) {
+		t.Parallel()
+
+		method, path, id, ok := ex.RequestInfo(userErr.Reason("no rows"))
+
+		require.False(t, ok)
+		require.Empty(t, method)
+		require.Empty(t, path)
+		require.Empty(t, id)
+		require.NoError(t, ex.WithRequest(nil, "GET", "/", "req-3"))
+	})
+}
@@ -0,0 +1,24 @@
+package ex
+
+// retryableKey is the metadata key marking the retryable errors.
+type retryableKey struct{}
+
+// WithRetryable marks the outermost link of the error as retryable or not, e.g. when only the caller knows
+// whether the operation is idempotent. The mark wins over the one registered against the identity.
+func WithRetryable(err error, retryable bool) error {
+	return attach(err, retryableKey{}, retryable)
+}
+
+// RegisterRetryable registers the identity as retryable: the operation may succeed if it is repeated
+// (like a timeout or a rate limit), so every link of a chain with the identity inherits the mark.
+func RegisterRetryable(identity Error) {
+	register(identity, retryableKey{}, true)
+}
+
+// Retryable reports whether the nearest link of the error chain marked with WithRetryable or RegisterRetryable
+// is retryable, e.g. for a retry loop or a gateway. Errors without a mark are not retryable.
+func Retryable(err error) bool {
+	retryable, _ := lookup[bool](err, retryableKey{})
+
+	return retryable
+}
@@ -0,0 +1,48 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestRetryable(t *testing.T) {
+	t.Parallel()
+
+	const (
+		limitErr    = ex.Error("retry test: rate limited")
+		checkoutErr = ex.Error("retry test: checkout failed")
+	)
+
+	ex.RegisterRetryable(limitErr)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want bool
+	}{
+		{name: "registered identity", args: args{err: limitErr}, want: true},
+		{name: "registered cause", args: args{err: checkoutErr.Because(limitErr.Reason("100 rps"))}, want: true},
+		{name: "instance overrides registered", args: args{err: ex.WithRetryable(limitErr, false)}, want: false},
+		{name: "marked instance", args: args{err: ex.WithRetryable(checkoutErr.Reason("timeout"), true)}, want: true},
+		{name: "nearest wins", args: args{err: ex.WithRetryable(checkoutErr.Because(limitErr), false)}, want: false},
+		{name: "not marked", args: args{err: checkoutErr.Reason("card declined")}, want: false},
+		{name: "standard error", args: args{err: errors.New("retry test: rate limited")}, want: false},
+		{name: "nothing passed", args: args{err: nil}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, ex.Retryable(test.args.err))
+		})
+	}
+}
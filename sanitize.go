@@ -0,0 +1,101 @@
+package ex
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sanitized returns a copy of the error chain whose link messages are safe to write to logs and terminals:
+// ANSI escape sequences are stripped, newlines, carriage returns and tabs are escaped as "\n", "\r" and "\t",
+// and any other control characters are removed. This protects log integrity and prevents log injection
+// through user-provided strings. Metadata of the links is preserved.
+//
+// It is a rendering transform: every link of the result gets a new Error identity with the cleaned message,
+// so errors.Is against the original identities (and standard errors) may no longer match.
+// Sanitize right before the output, not in the middle of error handling.
+// If the error is nil, the result error will also be nil.
+func Sanitized(err error) error {
+	levels, tail := unfold(err)
+
+	clean := make([]*xError, 0, len(levels))
+	for _, level := range levels {
+		link := *level
+		link.error = Error(sanitize(level.error.Error()))
+
+		clean = append(clean, &link)
+	}
+
+	if tail != nil {
+		tail = Error(sanitize(tail.Error()))
+	}
+
+	return fold(clean, tail)
+}
+
+// sanitize strips the ANSI escape sequences and control characters from the message.
+func sanitize(message string) string {
+	if !strings.ContainsFunc(message, unicode.IsControl) {
+		return message
+	}
+
+	var builder strings.Builder
+
+	builder.Grow(len(message))
+
+	for i := 0; i < len(message); {
+		r, size := utf8.DecodeRuneInString(message[i:])
+
+		switch {
+		case r == '\x1b':
+			i += escapeLen(message[i:])
+
+			continue
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case unicode.IsControl(r):
+		default:
+			builder.WriteString(message[i : i+size])
+		}
+
+		i += size
+	}
+
+	return builder.String()
+}
+
+// escapeLen returns the length of the ANSI escape sequence at the start of the text:
+// CSI sequences ("\x1b[31m") end with a final byte, OSC sequences ("\x1b]0;title\a") end with BEL or ST,
+// and any other escape takes the following character.
+func escapeLen(text string) int {
+	if len(text) < 2 { //nolint:mnd // the escape and the introducer
+		return len(text)
+	}
+
+	switch text[1] {
+	case '[':
+		for i := 2; i < len(text); i++ {
+			if text[i] >= 0x40 && text[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(text); i++ {
+			if text[i] == '\a' {
+				return i + 1
+			}
+
+			if text[i] == '\x1b' && i+1 < len(text) && text[i+1] == '\\' {
+				return i + 2 //nolint:mnd // the string terminator
+			}
+		}
+	default:
+		return 2 //nolint:mnd // the escape and the following byte
+	}
+
+	return len(text)
+}
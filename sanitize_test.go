@@ -0,0 +1,99 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestSanitized(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{
+			name: "newlines",
+			args: args{err: ex.Error("login failed\nINFO admin logged in").Reason("bad\r\npassword")},
+			want: `login failed\nINFO admin logged in: bad\r\npassword`,
+		},
+		{
+			name: "ansi colors",
+			args: args{err: ex.Error("\x1b[31mred\x1b[0m alert").Because(errors.New("\x1b[1;32mok\x1b[m"))},
+			want: "red alert: ok",
+		},
+		{
+			name: "terminal title",
+			args: args{
+				err: ex.Error("\x1b]0;pwned\aquery failed").Reason("\x1b]8;;http://evil\x1b\\click\x1b]8;;\x1b\\"),
+			},
+			want: "query failed: click",
+		},
+		{
+			name: "other controls",
+			args: args{err: ex.Error("bell\a null\x00 del\x7f c1\u0085 tab\t").Reason("ключ\x1bc")},
+			want: `bell null del c1 tab\t: ключ`,
+		},
+		{
+			name: "dangling escape",
+			args: args{err: ex.Error("cut \x1b[31").Reason("end\x1b")},
+			want: "cut : end",
+		},
+		{
+			name: "clean chain",
+			args: args{err: ex.Error("user not found").Because(ex.Error("database error").Reason("timeout"))},
+			want: "user not found: database error: timeout",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.Sanitized(test.args.err)
+
+			require.EqualError(t, got, test.want)
+
+			for _, link := range ex.ExposeAll(got) {
+				require.NotContains(t, link.Identity.Error(), "\n")
+				require.NotContains(t, link.Identity.Error(), "\x1b")
+			}
+		})
+	}
+
+	t.Run("rendering transform", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			dirtyErr = ex.Error("dirty\nidentity")
+			cleanErr = ex.Error("clean identity")
+		)
+
+		err := ex.WithAttempts(cleanErr.Because(dirtyErr.Reason("why")), 2)
+		got := ex.Sanitized(err)
+
+		attempts, ok := ex.Attempts(got)
+
+		require.True(t, ok)
+		require.Equal(t, 2, attempts)
+		require.True(t, ex.IsReason(ex.Promote(got)))
+		require.ErrorIs(t, got, cleanErr)
+		require.NotErrorIs(t, got, dirtyErr)
+		require.ErrorIs(t, err, dirtyErr)
+	})
+
+	t.Run("nothing passed", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ex.Sanitized(nil))
+	})
+}
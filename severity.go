@@ -0,0 +1,71 @@
+package ex
+
+// severityKey is the metadata key of the severity.
+type severityKey struct{}
+
+// Severity is the level of the error, e.g. for choosing the log level or whether to crash.
+type Severity uint8
+
+const (
+	// SeverityDebug represents an error interesting only while debugging.
+	SeverityDebug Severity = iota + 1
+	// SeverityInfo represents an expected error, e.g. a validation failure.
+	SeverityInfo
+	// SeverityWarn represents an error worth attention, but not a failure.
+	SeverityWarn
+	// SeverityError represents a failure of the operation.
+	SeverityError
+	// SeverityFatal represents a non-recoverable failure of the process.
+	SeverityFatal
+)
+
+// String returns the lowercase name of the severity, e.g. "warn".
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// WithSeverity attaches the severity to the outermost link of the error.
+func WithSeverity(err error, severity Severity) error {
+	return attach(err, severityKey{}, severity)
+}
+
+// RegisterSeverity registers the severity of the identity, so every link of a chain with the identity inherits it.
+func RegisterSeverity(identity Error, severity Severity) {
+	register(identity, severityKey{}, severity)
+}
+
+// SeverityOf returns the severity of the nearest link of the error chain that has one.
+// The severity attached to the link wins over the one registered against its identity.
+func SeverityOf(err error) (Severity, bool) {
+	return lookup[Severity](err, severityKey{})
+}
+
+// PanicIfFatal is the severity-aware Panic: it panics like Panic does only if the severity of the error is
+// SeverityFatal. For any other severity (or none) the critical error is passed to the OnCritical hooks,
+// so it still gets reported, and PanicIfFatal returns. The contract of Panic itself is unchanged.
+func PanicIfFatal(err error) {
+	if err == nil {
+		return
+	}
+
+	if severity, ok := SeverityOf(err); ok && severity == SeverityFatal {
+		Panic(err)
+
+		return
+	}
+
+	critical(&xError{error: ErrCritical, cause: err, meta: nil, text: "", flags: 0})
+}
@@ -0,0 +1,98 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestSeverityOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		configErr = ex.Error("severity test: bad config")
+		cacheErr  = ex.Error("severity test: cache miss")
+		plainErr  = ex.Error("severity test: plain")
+	)
+
+	ex.RegisterSeverity(configErr, ex.SeverityFatal)
+	ex.RegisterSeverity(cacheErr, ex.SeverityDebug)
+
+	t.Run("registered", func(t *testing.T) {
+		t.Parallel()
+
+		severity, ok := ex.SeverityOf(plainErr.Because(configErr.Reason("missing port")))
+
+		require.True(t, ok)
+		require.Equal(t, ex.SeverityFatal, severity)
+	})
+
+	t.Run("attached wins", func(t *testing.T) {
+		t.Parallel()
+
+		severity, ok := ex.SeverityOf(ex.WithSeverity(cacheErr.Reason("expired"), ex.SeverityWarn))
+
+		require.True(t, ok)
+		require.Equal(t, ex.SeverityWarn, severity)
+	})
+
+	t.Run("no severity", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), plainErr.Reason("why")} {
+			severity, ok := ex.SeverityOf(err)
+
+			require.False(t, ok)
+			require.Zero(t, severity)
+		}
+	})
+
+	t.Run("names", func(t *testing.T) {
+		t.Parallel()
+
+		names := make([]string, 0)
+		for _, severity := range []ex.Severity{
+			0, ex.SeverityDebug, ex.SeverityInfo, ex.SeverityWarn, ex.SeverityError, ex.SeverityFatal,
+		} {
+			names = append(names, severity.String())
+		}
+
+		require.Equal(t, []string{"unknown", "debug", "info", "warn", "error", "fatal"}, names)
+	})
+}
+
+//nolint:paralleltest // registers a process-wide hook
+func TestPanicIfFatal(t *testing.T) {
+	const severityErr = ex.Error("severity test: failure")
+
+	var calls []string
+
+	t.Cleanup(ex.OnCritical(func(err error) {
+		if errors.Is(err, severityErr) {
+			calls = append(calls, err.Error())
+		}
+	}))
+
+	t.Run("fatal", func(t *testing.T) {
+		require.PanicsWithError(t, "critical: severity test: failure", func() {
+			ex.PanicIfFatal(ex.WithSeverity(severityErr, ex.SeverityFatal))
+		})
+
+		require.Equal(t, []string{"critical: severity test: failure"}, calls)
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		calls = nil
+
+		require.NotPanics(t, func() {
+			ex.PanicIfFatal(ex.WithSeverity(severityErr.Reason("slow"), ex.SeverityWarn))
+			ex.PanicIfFatal(severityErr)
+			ex.PanicIfFatal(nil)
+		})
+
+		require.Equal(t, []string{"critical: severity test: failure: slow", "critical: severity test: failure"}, calls)
+	})
+}
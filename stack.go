@@ -0,0 +1,118 @@
+package ex
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// stackDepth is the maximum number of captured frames.
+const stackDepth = 32
+
+// packagePath is the import path of the package, the prefix of its function names in frames.
+const packagePath = "github.com/therenotomorrow/ex"
+
+// stackKey is the metadata key of the captured call stack.
+type stackKey struct{}
+
+// stack is the program counters of a captured call stack.
+type stack []uintptr
+
+// WithStack captures the call stack of the caller and attaches it to the outermost link of the error.
+// The stack is shown by Dump and %+v and can be read with FirstStack.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return attach(err, stackKey{}, callers(1))
+}
+
+// HasStack checks if a call stack is captured anywhere in the error chain.
+func HasStack(err error) bool {
+	_, ok := lookup[stack](err, stackKey{})
+
+	return ok
+}
+
+// FirstStack returns the frames of the outermost call stack captured in the error chain.
+// If there is no captured stack, the result will be nil.
+func FirstStack(err error) []runtime.Frame {
+	trace, ok := lookup[stack](err, stackKey{})
+	if !ok {
+		return nil
+	}
+
+	return trace.frames()
+}
+
+// Source returns the top frame of the outermost call stack captured in the error chain,
+// e.g. the place where Critical or Panic fired.
+func Source(err error) (runtime.Frame, bool) {
+	frames := FirstStack(err)
+	if len(frames) == 0 {
+		var zero runtime.Frame
+
+		return zero, false
+	}
+
+	return frames[0], true
+}
+
+// external captures the call stack above the frames of the package itself,
+// so the top frame is the code that called into it, however deep the package calls went.
+func external() stack {
+	trace := callers(1)
+
+	skip := 0
+
+	frames := runtime.CallersFrames(trace)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") || !more {
+			break
+		}
+
+		skip++
+	}
+
+	return callers(1 + skip)
+}
+
+// callers captures the call stack, skipping the frames of callers and its caller.
+func callers(skip int) stack {
+	pcs := make([]uintptr, stackDepth)
+
+	return pcs[:runtime.Callers(skip+2, pcs)]
+}
+
+// frames resolves the program counters into frames.
+func (s stack) frames() []runtime.Frame {
+	list := make([]runtime.Frame, 0, len(s))
+
+	frames := runtime.CallersFrames(s)
+	for {
+		frame, more := frames.Next()
+		list = append(list, frame)
+
+		if !more {
+			return list
+		}
+	}
+}
+
+// String renders the frames on their own indented lines, as shown by Dump.
+func (s stack) String() string {
+	var builder strings.Builder
+
+	for _, frame := range s.frames() {
+		builder.WriteString("\n        ")
+		builder.WriteString(frame.Function)
+		builder.WriteString("\n            ")
+		builder.WriteString(frame.File)
+		builder.WriteByte(':')
+		builder.WriteString(strconv.Itoa(frame.Line))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,135 @@
+package ex_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func innerStack(err error) error {
+	return ex.WithStack(err)
+}
+
+func outerStack(err error) error {
+	return ex.WithStack(err)
+}
+
+func TestFirstStack(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	t.Run("with stack", func(t *testing.T) {
+		t.Parallel()
+
+		err := userErr.Because(innerStack(dbErr.Reason("no rows")))
+
+		require.True(t, ex.HasStack(err))
+		require.EqualError(t, err, "user not found: database error: no rows")
+
+		frames := ex.FirstStack(err)
+
+		require.NotEmpty(t, frames)
+		require.True(t, strings.HasSuffix(frames[0].Function, "ex_test.innerStack"))
+		require.True(t, strings.HasSuffix(frames[1].Function, "ex_test.TestFirstStack.func1"))
+		require.Contains(
+			t,
+			fmt.Sprintf("%+v", err),
+			"    stack:\n        github.com/therenotomorrow/ex_test.innerStack\n",
+		)
+	})
+
+	t.Run("without stack", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), userErr.Because(dbErr)} {
+			require.False(t, ex.HasStack(err))
+			require.Nil(t, ex.FirstStack(err))
+		}
+
+		require.NoError(t, ex.WithStack(nil))
+	})
+
+	t.Run("stacks at two levels", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			inner = innerStack(dbErr.Reason("no rows"))
+			err   = outerStack(ex.Conv(userErr).Because(inner))
+		)
+
+		require.True(t, ex.HasStack(err))
+		require.True(t, strings.HasSuffix(ex.FirstStack(err)[0].Function, "ex_test.outerStack"))
+		require.True(t, strings.HasSuffix(ex.FirstStack(inner)[0].Function, "ex_test.innerStack"))
+	})
+}
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	const siteErr = ex.Error("panic site error")
+
+	recovered := func(fn func()) (err error) {
+		defer func() { err = ex.FromPanic(recover()) }()
+
+		fn()
+
+		return nil
+	}
+
+	type args struct {
+		fn func()
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want string
+	}{
+		{name: "panic", args: args{fn: func() { ex.Panic(siteErr) }}, want: "TestSource.func2"},
+		{name: "critical", args: args{fn: func() { _ = ex.Critical("", siteErr) }}, want: "TestSource.func3"},
+		{
+			name: "must with",
+			args: args{fn: func() { _ = ex.MustWith(siteErr, 0, errors.New("io")) }},
+			want: "TestSource.func4",
+		},
+		{
+			name: "panic if fatal",
+			args: args{fn: func() { ex.PanicIfFatal(ex.WithSeverity(siteErr, ex.SeverityFatal)) }},
+			want: "TestSource.func5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := recovered(test.args.fn)
+
+			require.ErrorIs(t, err, siteErr)
+
+			frame, ok := ex.Source(err)
+
+			require.True(t, ok)
+			require.True(t, strings.HasSuffix(frame.Function, "ex_test."+test.want), frame.Function)
+			require.Contains(t, fmt.Sprintf("%+v", err), "    stack:\n        "+frame.Function+"\n")
+		})
+	}
+
+	t.Run("no stack", func(t *testing.T) {
+		t.Parallel()
+
+		frame, ok := ex.Source(siteErr.Reason("no panic"))
+
+		require.False(t, ok)
+		require.Empty(t, frame.Function)
+	})
+}
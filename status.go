@@ -0,0 +1,21 @@
+package ex
+
+// statusKey is the metadata key of the HTTP status.
+type statusKey struct{}
+
+// WithHTTPStatus attaches the HTTP status to the outermost link of the error, e.g. the status a handler responds with.
+// The attached status wins over the one registered against the identity.
+func WithHTTPStatus(err error, status int) error {
+	return attach(err, statusKey{}, status)
+}
+
+// RegisterHTTPStatus registers the HTTP status of the identity, so every link of a chain with the identity
+// inherits it. Unlike the code (see RegisterCode), the status is meant for the transport only.
+func RegisterHTTPStatus(identity Error, status int) {
+	register(identity, statusKey{}, status)
+}
+
+// HTTPStatusOf returns the HTTP status of the nearest link of the error chain that has one.
+func HTTPStatusOf(err error) (int, bool) {
+	return lookup[int](err, statusKey{})
+}
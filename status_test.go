@@ -0,0 +1,50 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestHTTPStatusOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		limitErr    = ex.Error("status test: rate limited")
+		checkoutErr = ex.Error("status test: checkout failed")
+	)
+
+	ex.RegisterHTTPStatus(limitErr, 429)
+
+	t.Run("registered", func(t *testing.T) {
+		t.Parallel()
+
+		status, ok := ex.HTTPStatusOf(checkoutErr.Because(limitErr.Reason("100 rps")))
+
+		require.True(t, ok)
+		require.Equal(t, 429, status)
+	})
+
+	t.Run("instance overrides registered", func(t *testing.T) {
+		t.Parallel()
+
+		status, ok := ex.HTTPStatusOf(ex.WithHTTPStatus(limitErr.Reason("quota"), 503))
+
+		require.True(t, ok)
+		require.Equal(t, 503, status)
+	})
+
+	t.Run("no status", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range []error{nil, errors.New("standard"), checkoutErr.Reason("why")} {
+			status, ok := ex.HTTPStatusOf(err)
+
+			require.False(t, ok)
+			require.Zero(t, status)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package ex
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Summary groups a batch of errors by the sentinel of their outermost identity, e.g. for "by type" reports.
+// Standard errors (and the chains without a sentinel) are grouped under the empty Error.
+type Summary map[Error]SummaryGroup
+
+// SummaryGroup is a single group of the Summary.
+type SummaryGroup struct {
+	Sample error  // The first seen error of the group.
+	Label  string // The slug of the identity (see LabelKey) displayed for the group.
+	Count  int    // The number of errors in the group.
+}
+
+// jsonGroup is the JSON representation of a SummaryGroup.
+type jsonGroup struct {
+	Label    string `json:"label"`
+	Identity string `json:"identity"`
+	Sample   string `json:"sample"`
+	Count    int    `json:"count"`
+}
+
+// Summarize groups the errors by the sentinel of their outermost identity, so the dynamic causes
+// and interpolated arguments don't explode the groups. The slug of the identity (see LabelKey) is only
+// the label of the group, so distinct identities with the same slug stay apart, and so do standard errors
+// and ErrUnknown chains, though both are labeled "unknown".
+// Nil errors are skipped. If there are no errors, the result is an empty Summary.
+func Summarize(errs []error) Summary {
+	summary := make(Summary)
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		sentinel, _ := sentinelOf(err)
+
+		group, ok := summary[sentinel]
+		if !ok {
+			group.Sample, group.Label = err, LabelKey(err)
+		}
+
+		group.Count++
+		summary[sentinel] = group
+	}
+
+	return summary
+}
+
+// String renders the human report: a line per group, the largest groups first (then by the label),
+// e.g. "user_not_found: 3 (user not found: id 42)" with the sample error in parentheses.
+func (s Summary) String() string {
+	lines := make([]string, 0, len(s))
+	for _, key := range s.keys() {
+		lines = append(lines, s[key].Label+": "+strconv.Itoa(s[key].Count)+" ("+s[key].Sample.Error()+")")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// MarshalJSON encodes the summary as an array of the groups ordered like String does, e.g.
+// [{"label":"user_not_found","identity":"user not found","sample":"user not found: id 42","count":3}].
+// Standard errors have the empty identity.
+func (s Summary) MarshalJSON() ([]byte, error) {
+	groups := make([]jsonGroup, 0, len(s))
+	for _, key := range s.keys() {
+		groups = append(groups, jsonGroup{
+			Label:    s[key].Label,
+			Identity: string(key),
+			Sample:   s[key].Sample.Error(),
+			Count:    s[key].Count,
+		})
+	}
+
+	return json.Marshal(groups)
+}
+
+// keys returns the sentinels of the groups, the largest groups first, then by the label and the sentinel.
+func (s Summary) keys() []Error {
+	return slices.SortedFunc(maps.Keys(s), func(a, b Error) int {
+		return cmp.Or(cmp.Compare(s[b].Count, s[a].Count), cmp.Compare(s[a].Label, s[b].Label), cmp.Compare(a, b))
+	})
+}
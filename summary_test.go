@@ -0,0 +1,99 @@
+package ex_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr  = ex.Error("user not found")
+		dbErr    = ex.Error("database error")
+		quotaErr = ex.Error("quota %d exceeded")
+		legacy   = ex.Error("User-Not-Found")
+	)
+
+	first := userErr.Reason("id 42")
+
+	errs := []error{
+		first,
+		dbErr.Because(errors.New("connection reset")),
+		userErr.Reason("id 7"),
+		nil,
+		errors.New("plain"),
+		quotaErr.With(10),
+		userErr.Because(dbErr.Reason("timeout")),
+		quotaErr.With(20),
+		dbErr.Reason("deadlock"),
+		errors.New("another plain"),
+		ex.Unknown(errors.New("lost")),
+		legacy.Reason("id 1"),
+	}
+
+	t.Run("groups", func(t *testing.T) {
+		t.Parallel()
+
+		summary := ex.Summarize(errs)
+
+		require.Equal(t, ex.Summary{
+			userErr:       {Sample: first, Label: "user_not_found", Count: 3},
+			dbErr:         {Sample: errs[1], Label: "database_error", Count: 2},
+			"":            {Sample: errs[4], Label: "unknown", Count: 2},
+			quotaErr:      {Sample: errs[5], Label: "quota_d_exceeded", Count: 2},
+			ex.ErrUnknown: {Sample: errs[10], Label: "unknown", Count: 1},
+			legacy:        {Sample: errs[11], Label: "user_not_found", Count: 1},
+		}, summary)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, ""+
+			"user_not_found: 3 (user not found: id 42)\n"+
+			"database_error: 2 (database error: connection reset)\n"+
+			"quota_d_exceeded: 2 (quota 10 exceeded)\n"+
+			"unknown: 2 (plain)\n"+
+			"unknown: 1 (unknown: lost)\n"+
+			"user_not_found: 1 (User-Not-Found: id 1)", ex.Summarize(errs).String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(ex.Summarize(append(errs[:5:5], errs[11])))
+
+		require.NoError(t, err)
+		require.JSONEq(t, `[
+			{"label": "user_not_found", "identity": "user not found", "sample": "user not found: id 42", "count": 2},
+			{
+				"label": "database_error", "identity": "database error",
+				"sample": "database error: connection reset", "count": 1
+			},
+			{"label": "unknown", "identity": "", "sample": "plain", "count": 1},
+			{"label": "user_not_found", "identity": "User-Not-Found", "sample": "User-Not-Found: id 1", "count": 1}
+		]`, string(data))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		for _, batch := range [][]error{nil, {nil, nil}} {
+			summary := ex.Summarize(batch)
+
+			require.Empty(t, summary)
+			require.Empty(t, summary.String())
+
+			data, err := json.Marshal(summary)
+
+			require.NoError(t, err)
+			require.JSONEq(t, `[]`, string(data))
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package ex
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limiter throttles hooks (like the ones of OnCritical and SetExposeHook), so a storm of errors
+// with the same identity can't flood whatever the hook writes to. It lets at most the limit of errors
+// per identity through in every interval and counts the rest as dropped.
+//
+// The limiter never blocks: it keeps lock-free atomic counters per identity, so under concurrency
+// the limit holds exactly within an interval and approximately at the interval boundaries.
+// The identity is the sentinel of the outermost link, so the errors created with Error.With share
+// the bucket of their sentinel whatever the arguments, and all standard errors share a single empty identity.
+// The limiter keeps at most 1024 identities, the identities seen after that share the LimiterOverflow one.
+type Limiter struct {
+	buckets  sync.Map // identity → *bucket
+	size     atomic.Int64
+	limit    int64
+	interval int64
+}
+
+// LimiterOverflow is the identity shared by the errors over the identities the Limiter keeps.
+const LimiterOverflow = "(overflow)"
+
+// maxBuckets bounds the number of identities of the Limiter, so dynamic identities can't grow it endlessly.
+const maxBuckets = 1024
+
+// LimiterStats are the counters of a single identity of the Limiter.
+type LimiterStats struct {
+	Passed  uint64 // The number of errors let through.
+	Dropped uint64 // The number of errors dropped over the limit.
+}
+
+// bucket holds the counters of a single identity.
+type bucket struct {
+	window  atomic.Int64
+	count   atomic.Int64
+	passed  atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// NewLimiter creates a Limiter letting at most the limit of errors per identity through in every interval.
+// A not positive limit drops everything, a not positive interval means a single endless interval.
+func NewLimiter(limit int, interval time.Duration) *Limiter {
+	return &Limiter{buckets: sync.Map{}, size: atomic.Int64{}, limit: int64(limit), interval: int64(max(interval, 0))}
+}
+
+// Allow reports whether the error is let through, counting it either way.
+func (l *Limiter) Allow(err error) bool {
+	counters := l.bucket(identityKey(err))
+
+	if l.interval > 0 {
+		window := time.Now().UnixNano() / l.interval
+		if current := counters.window.Load(); current != window && counters.window.CompareAndSwap(current, window) {
+			counters.count.Store(0)
+		}
+	}
+
+	if counters.count.Add(1) > l.limit {
+		counters.dropped.Add(1)
+
+		return false
+	}
+
+	counters.passed.Add(1)
+
+	return true
+}
+
+// bucket returns the counters of the identity, adding them if there is still room for a new identity.
+func (l *Limiter) bucket(key string) *bucket {
+	value, ok := l.buckets.Load(key)
+	if !ok {
+		if l.size.Load() >= maxBuckets {
+			key = LimiterOverflow
+		}
+
+		var loaded bool
+		if value, loaded = l.buckets.LoadOrStore(key, new(bucket)); !loaded {
+			l.size.Add(1)
+		}
+	}
+
+	counters, _ := value.(*bucket)
+
+	return counters
+}
+
+// Wrap returns the hook calling fn only for the errors the limiter lets through.
+func (l *Limiter) Wrap(fn func(err error)) func(err error) {
+	return func(err error) {
+		if l.Allow(err) {
+			fn(err)
+		}
+	}
+}
+
+// Stats returns a snapshot of the counters of every identity seen by the limiter.
+func (l *Limiter) Stats() map[string]LimiterStats {
+	stats := make(map[string]LimiterStats)
+
+	l.buckets.Range(func(key, value any) bool {
+		identity, _ := key.(string)
+		counters, _ := value.(*bucket)
+
+		stats[identity] = LimiterStats{Passed: counters.passed.Load(), Dropped: counters.dropped.Load()}
+
+		return true
+	})
+
+	return stats
+}
+
+// identityKey returns the sentinel of the outermost link of the chain, or "" for standard errors.
+func identityKey(err error) string {
+	sentinel, _ := sentinelOf(err)
+
+	return string(sentinel)
+}
@@ -0,0 +1,179 @@
+package ex_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	t.Parallel()
+
+	const (
+		errFirst  = ex.Error("first")
+		errSecond = ex.Error("second")
+	)
+
+	type args struct {
+		errs  []error
+		limit int
+	}
+
+	type want struct {
+		stats  map[string]ex.LimiterStats
+		passed []bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "under the limit",
+			args: args{errs: []error{errFirst, errFirst.Reason("why")}, limit: 2},
+			want: want{
+				stats:  map[string]ex.LimiterStats{"first": {Passed: 2, Dropped: 0}},
+				passed: []bool{true, true},
+			},
+		},
+		{
+			name: "over the limit",
+			args: args{errs: []error{errFirst, errFirst, errSecond, errFirst}, limit: 1},
+			want: want{
+				stats: map[string]ex.LimiterStats{
+					"first":  {Passed: 1, Dropped: 2},
+					"second": {Passed: 1, Dropped: 0},
+				},
+				passed: []bool{true, false, true, false},
+			},
+		},
+		{
+			name: "standard errors",
+			args: args{errs: []error{errors.New("one"), errors.New("two"), nil}, limit: 2},
+			want: want{
+				stats:  map[string]ex.LimiterStats{"": {Passed: 2, Dropped: 1}},
+				passed: []bool{true, true, false},
+			},
+		},
+		{
+			name: "interpolated identity",
+			args: args{errs: []error{ex.Error("user %d").With(1), ex.Error("user %d").With(2), errFirst}, limit: 1},
+			want: want{
+				stats: map[string]ex.LimiterStats{
+					"user %d": {Passed: 1, Dropped: 1},
+					"first":   {Passed: 1, Dropped: 0},
+				},
+				passed: []bool{true, false, true},
+			},
+		},
+		{
+			name: "not positive limit",
+			args: args{errs: []error{errFirst}, limit: 0},
+			want: want{
+				stats:  map[string]ex.LimiterStats{"first": {Passed: 0, Dropped: 1}},
+				passed: []bool{false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := ex.NewLimiter(test.args.limit, time.Hour)
+
+			passed := make([]bool, 0, len(test.args.errs))
+			for _, err := range test.args.errs {
+				passed = append(passed, limiter.Allow(err))
+			}
+
+			require.Equal(t, test.want.passed, passed)
+			require.Equal(t, test.want.stats, limiter.Stats())
+		})
+	}
+}
+
+func TestLimiterOverflow(t *testing.T) {
+	t.Parallel()
+
+	const identities = 1024
+
+	limiter := ex.NewLimiter(1, time.Hour)
+
+	for i := range identities {
+		require.True(t, limiter.Allow(ex.Error("identity "+strconv.Itoa(i))))
+	}
+
+	require.True(t, limiter.Allow(ex.Error("one more")))
+	require.False(t, limiter.Allow(ex.Error("and another")))
+	require.False(t, limiter.Allow(ex.Error("identity 0")))
+
+	stats := limiter.Stats()
+
+	require.Len(t, stats, identities+1)
+	require.Equal(t, ex.LimiterStats{Passed: 1, Dropped: 1}, stats[ex.LimiterOverflow])
+	require.Equal(t, ex.LimiterStats{Passed: 1, Dropped: 1}, stats["identity 0"])
+}
+
+func TestLimiterInterval(t *testing.T) {
+	t.Parallel()
+
+	const errThrottled = ex.Error("throttled")
+
+	limiter := ex.NewLimiter(1, 10*time.Millisecond)
+
+	require.True(t, limiter.Allow(errThrottled))
+	require.False(t, limiter.Allow(errThrottled))
+
+	require.Eventually(t, func() bool {
+		return limiter.Allow(errThrottled)
+	}, time.Second, time.Millisecond)
+
+	endless := ex.NewLimiter(1, 0)
+
+	require.True(t, endless.Allow(errThrottled))
+	require.False(t, endless.Allow(errThrottled))
+}
+
+func TestLimiterWrap(t *testing.T) {
+	t.Parallel()
+
+	const (
+		errStorm   = ex.Error("storm")
+		goroutines = 8
+		events     = 1000
+		limit      = 100
+	)
+
+	var calls atomic.Int64
+
+	limiter := ex.NewLimiter(limit, time.Hour)
+	hook := limiter.Wrap(func(_ error) {
+		calls.Add(1)
+	})
+
+	var group sync.WaitGroup
+
+	for range goroutines {
+		group.Go(func() {
+			for range events {
+				hook(errStorm.Reason("retry"))
+			}
+		})
+	}
+
+	group.Wait()
+
+	require.Equal(t, int64(limit), calls.Load())
+	require.Equal(t, map[string]ex.LimiterStats{
+		"storm": {Passed: limit, Dropped: goroutines*events - limit},
+	}, limiter.Stats())
+}
@@ -0,0 +1,106 @@
+package ex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"io/fs"
+	"sync"
+)
+
+const (
+	// ErrNotFound represents a missing resource, e.g. sql.ErrNoRows or fs.ErrNotExist.
+	ErrNotFound Error = "not found"
+
+	// ErrAlreadyExists represents a resource that already exists, e.g. fs.ErrExist.
+	ErrAlreadyExists Error = "already exists"
+
+	// ErrPermission represents a denied access, e.g. fs.ErrPermission.
+	ErrPermission Error = "permission denied"
+
+	// ErrEndOfInput represents the exhausted input, e.g. io.EOF or io.ErrUnexpectedEOF.
+	ErrEndOfInput Error = "end of input"
+
+	// ErrClosed represents an operation on a closed resource, e.g. fs.ErrClosed or sql.ErrConnDone.
+	ErrClosed Error = "closed"
+
+	// ErrCanceled represents a canceled operation, e.g. context.Canceled.
+	ErrCanceled Error = "canceled"
+
+	// ErrTimeout represents an operation that ran out of time, e.g. context.DeadlineExceeded.
+	ErrTimeout Error = "timeout"
+)
+
+// translation maps the standard error to the identity it is translated to.
+type translation struct {
+	target   error
+	identity Error
+}
+
+// translations holds the table of TranslateStdlib, the registered entries come after the prebuilt ones.
+//
+//nolint:gochecknoglobals // the table is process-wide by design
+var translations = struct {
+	entries []translation
+	mu      sync.RWMutex
+}{
+	entries: []translation{
+		{target: sql.ErrNoRows, identity: ErrNotFound},
+		{target: fs.ErrNotExist, identity: ErrNotFound},
+		{target: fs.ErrExist, identity: ErrAlreadyExists},
+		{target: fs.ErrPermission, identity: ErrPermission},
+		{target: io.EOF, identity: ErrEndOfInput},
+		{target: io.ErrUnexpectedEOF, identity: ErrEndOfInput},
+		{target: fs.ErrClosed, identity: ErrClosed},
+		{target: io.ErrClosedPipe, identity: ErrClosed},
+		{target: sql.ErrConnDone, identity: ErrClosed},
+		{target: sql.ErrTxDone, identity: ErrClosed},
+		{target: context.Canceled, identity: ErrCanceled},
+		{target: context.DeadlineExceeded, identity: ErrTimeout},
+	},
+	mu: sync.RWMutex{},
+}
+
+// RegisterTranslation extends the table of TranslateStdlib: errors matching the target with errors.Is
+// are translated to the identity. Later registrations take precedence, so the prebuilt entries can be overridden.
+func RegisterTranslation(target error, identity Error) {
+	if target == nil {
+		return
+	}
+
+	translations.mu.Lock()
+	defer translations.mu.Unlock()
+
+	translations.entries = append(translations.entries, translation{target: target, identity: identity})
+}
+
+// TranslateStdlib wraps the well-known standard library error (like io.EOF, sql.ErrNoRows or fs.ErrNotExist)
+// under the matching identity, e.g. ErrNotFound.Because(sql.ErrNoRows). The original error stays the cause,
+// so errors.Is keeps working against both the identity and the original.
+//
+// Unmapped errors, and errors that already carry the matching identity, are returned unchanged.
+func TranslateStdlib(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	translations.mu.RLock()
+	defer translations.mu.RUnlock()
+
+	for i := len(translations.entries) - 1; i >= 0; i-- {
+		entry := translations.entries[i]
+
+		if !errors.Is(err, entry.target) {
+			continue
+		}
+
+		if errors.Is(err, entry.identity) {
+			return err
+		}
+
+		return entry.identity.Because(err)
+	}
+
+	return err
+}
@@ -0,0 +1,104 @@
+package ex_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestTranslateStdlib(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		err error
+	}
+
+	type want struct {
+		identity error
+		message  string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "eof",
+			args: args{err: io.EOF},
+			want: want{identity: ex.ErrEndOfInput, message: "end of input: EOF"},
+		},
+		{
+			name: "no rows",
+			args: args{err: sql.ErrNoRows},
+			want: want{identity: ex.ErrNotFound, message: "not found: sql: no rows in result set"},
+		},
+		{
+			name: "wrapped not exist",
+			args: args{err: fmt.Errorf("open config: %w", os.ErrNotExist)},
+			want: want{identity: ex.ErrNotFound, message: "not found: open config: file does not exist"},
+		},
+		{
+			name: "deadline",
+			args: args{err: context.DeadlineExceeded},
+			want: want{identity: ex.ErrTimeout, message: "timeout: context deadline exceeded"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ex.TranslateStdlib(test.args.err)
+
+			require.ErrorIs(t, got, test.want.identity)
+			require.ErrorIs(t, got, test.args.err)
+			require.EqualError(t, got, test.want.message)
+		})
+	}
+
+	t.Run("unmapped", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.New("standard")
+
+		require.Same(t, err, ex.TranslateStdlib(err))
+		require.NoError(t, ex.TranslateStdlib(nil))
+	})
+
+	t.Run("already translated", func(t *testing.T) {
+		t.Parallel()
+
+		err := ex.ErrEndOfInput.Because(io.EOF)
+
+		require.Equal(t, err, ex.TranslateStdlib(err))
+	})
+}
+
+func TestRegisterTranslation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		throttled   = errors.New("translate test: throttled")
+		throttleErr = ex.Error("translate test: too many requests")
+	)
+
+	require.Same(t, throttled, ex.TranslateStdlib(throttled))
+
+	ex.RegisterTranslation(throttled, throttleErr)
+	ex.RegisterTranslation(nil, throttleErr)
+
+	got := ex.TranslateStdlib(throttled)
+
+	require.ErrorIs(t, got, throttleErr)
+	require.ErrorIs(t, got, throttled)
+	require.EqualError(t, got, "translate test: too many requests: translate test: throttled")
+}
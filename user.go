@@ -0,0 +1,24 @@
+package ex
+
+// userKey is the metadata key marking the user-facing identities.
+type userKey struct{}
+
+// RegisterUserError registers the identity as user-facing: the user can fix the error (like a validation failure),
+// unlike an internal bug. See IsUserError.
+func RegisterUserError(identity Error) {
+	register(identity, userKey{}, true)
+}
+
+// IsUserError reports whether the outermost identity of the error was registered with RegisterUserError,
+// e.g. for a CLI to show a friendly hint instead of a stack trace.
+// Only the outermost identity counts: a user error wrapped into an internal one is internal.
+func IsUserError(err error) bool {
+	sentinel, ok := sentinelOf(err)
+	if !ok {
+		return false
+	}
+
+	_, ok = registered(sentinel, userKey{})
+
+	return ok
+}
@@ -0,0 +1,47 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestIsUserError(t *testing.T) {
+	t.Parallel()
+
+	const (
+		validationErr = ex.Error("user test: invalid email")
+		internalErr   = ex.Error("user test: nil pointer")
+	)
+
+	ex.RegisterUserError(validationErr)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want bool
+	}{
+		{name: "user identity", args: args{err: validationErr}, want: true},
+		{name: "user chain", args: args{err: validationErr.Reason("missing @")}, want: true},
+		{name: "display identity", args: args{err: validationErr.With("field", "email")}, want: true},
+		{name: "internal identity", args: args{err: internalErr.Reason("config")}, want: false},
+		{name: "wrapped user identity", args: args{err: internalErr.Because(validationErr)}, want: false},
+		{name: "standard error", args: args{err: errors.New("user test: invalid email")}, want: false},
+		{name: "nothing passed", args: args{err: nil}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, ex.IsUserError(test.args.err))
+		})
+	}
+}
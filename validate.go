@@ -0,0 +1,81 @@
+package ex
+
+// IssueKind is the kind of the problem Validate found in an error chain.
+type IssueKind uint8
+
+const (
+	// IssueCycle represents a link that causes itself, directly or through other links.
+	IssueCycle IssueKind = iota + 1
+	// IssueNilIdentity represents a link without an identity.
+	IssueNilIdentity
+	// IssueEmptyMessage represents a link (or the terminating error) with an empty message.
+	IssueEmptyMessage
+	// IssueTooDeep represents a chain deeper than the limit set with SetMaxMatchDepth.
+	IssueTooDeep
+)
+
+// String returns the name of the kind, e.g. "cycle".
+func (k IssueKind) String() string {
+	switch k {
+	case IssueCycle:
+		return "cycle"
+	case IssueNilIdentity:
+		return "nil identity"
+	case IssueEmptyMessage:
+		return "empty message"
+	case IssueTooDeep:
+		return "too deep"
+	default:
+		return "unknown"
+	}
+}
+
+// Issue describes a problem Validate found in an error chain.
+type Issue struct {
+	Kind  IssueKind // The kind of the problem.
+	Depth int       // The zero-based depth of the offending link.
+}
+
+// Validate checks the invariants of the error chain, e.g. the one decoded with DecodeJSON or built dynamically,
+// before trusting it: no cycles, no links without identities or with empty messages and no more links than
+// the limit set with SetMaxMatchDepth. The walk stops at a cycle or at the limit.
+// The issues are listed from the outermost link down. If the chain is healthy or nil, the result will be nil.
+func Validate(err error) []Issue {
+	var (
+		issues []Issue
+		limit  = maxMatchDepth()
+		seen   = make(map[*xError]bool)
+	)
+
+	for depth := 0; err != nil; depth++ {
+		if int64(depth) >= limit {
+			return append(issues, Issue{Kind: IssueTooDeep, Depth: depth})
+		}
+
+		xer, ok := step(err)
+		if !ok {
+			if err.Error() == "" {
+				issues = append(issues, Issue{Kind: IssueEmptyMessage, Depth: depth})
+			}
+
+			break
+		}
+
+		if seen[xer] {
+			return append(issues, Issue{Kind: IssueCycle, Depth: depth})
+		}
+
+		seen[xer] = true
+
+		switch {
+		case xer.error == nil:
+			issues = append(issues, Issue{Kind: IssueNilIdentity, Depth: depth})
+		case xer.error.Error() == "":
+			issues = append(issues, Issue{Kind: IssueEmptyMessage, Depth: depth})
+		}
+
+		err = xer.because()
+	}
+
+	return issues
+}
@@ -0,0 +1,89 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userErr = ex.Error("user not found")
+		dbErr   = ex.Error("database error")
+	)
+
+	deep := make([]ex.Error, 10_001)
+	for i := range deep {
+		deep[i] = dbErr
+	}
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []ex.Issue
+	}{
+		{
+			name: "healthy",
+			args: args{err: userErr.Because(dbErr.Because(errors.New("connection reset")))},
+			want: nil,
+		},
+		{
+			name: "nothing passed",
+			args: args{err: nil},
+			want: nil,
+		},
+		{
+			name: "cycle",
+			args: args{err: userErr.Because(ex.Cycle(dbErr))},
+			want: []ex.Issue{{Kind: ex.IssueCycle, Depth: 2}},
+		},
+		{
+			name: "nil identity",
+			args: args{err: userErr.Because(ex.OnlyCause(dbErr.Reason("timeout")))},
+			want: []ex.Issue{{Kind: ex.IssueNilIdentity, Depth: 1}},
+		},
+		{
+			name: "empty message",
+			args: args{err: ex.Error("").Because(dbErr.Because(errors.New("")))},
+			want: []ex.Issue{{Kind: ex.IssueEmptyMessage, Depth: 0}, {Kind: ex.IssueEmptyMessage, Depth: 2}},
+		},
+		{
+			name: "too deep",
+			args: args{err: ex.WrapAll(errors.New("connection reset"), deep...)},
+			want: []ex.Issue{{Kind: ex.IssueTooDeep, Depth: 10_000}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, ex.Validate(test.args.err))
+		})
+	}
+}
+
+func TestIssueKind_String(t *testing.T) {
+	t.Parallel()
+
+	kinds := map[ex.IssueKind]string{
+		ex.IssueCycle:        "cycle",
+		ex.IssueNilIdentity:  "nil identity",
+		ex.IssueEmptyMessage: "empty message",
+		ex.IssueTooDeep:      "too deep",
+		ex.IssueKind(0):      "unknown",
+	}
+
+	for kind, want := range kinds {
+		require.Equal(t, want, kind.String())
+	}
+}
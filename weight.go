@@ -0,0 +1,45 @@
+package ex
+
+// weightKey is the metadata key of the weight.
+type weightKey struct{}
+
+// RegisterWeight registers the weight of the identity, summed up by Score, e.g. for health scoring.
+func RegisterWeight(identity Error, weight int) {
+	register(identity, weightKey{}, weight)
+}
+
+// Score returns the sum of the weights registered against the identities of the error chain.
+// Every identity is counted once, however many links it has, and identities without a weight contribute zero.
+// If the error is nil, the score is zero.
+func Score(err error) int {
+	levels, tail := unfold(err)
+
+	identities := make([]error, 0, len(levels)+1)
+	for _, level := range levels {
+		identities = append(identities, level.error)
+	}
+
+	if tail != nil {
+		identities = append(identities, tail)
+	}
+
+	var (
+		score int
+		seen  = make(map[Error]bool)
+	)
+
+	for _, identity := range identities {
+		sentinel, ok := sentinelOf(identity)
+		if !ok || seen[sentinel] {
+			continue
+		}
+
+		seen[sentinel] = true
+
+		value, _ := registered(sentinel, weightKey{})
+		weight, _ := value.(int)
+		score += weight
+	}
+
+	return score
+}
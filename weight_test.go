@@ -0,0 +1,50 @@
+package ex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/ex"
+)
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiErr     = ex.Error("weight test: api failed")
+		dbErr      = ex.Error("weight test: database error")
+		timeoutErr = ex.Error("weight test: timeout")
+		plainErr   = ex.Error("weight test: unregistered")
+	)
+
+	ex.RegisterWeight(apiErr, 1)
+	ex.RegisterWeight(dbErr, 5)
+	ex.RegisterWeight(timeoutErr, 3)
+
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		args args
+		name string
+		want int
+	}{
+		{name: "chain", args: args{err: apiErr.Because(plainErr.Because(dbErr.Because(timeoutErr)))}, want: 9},
+		{name: "reason", args: args{err: dbErr.Reason("weight test: timeout")}, want: 8},
+		{name: "repeated identity", args: args{err: dbErr.Because(dbErr.Reason("retry"))}, want: 5},
+		{name: "unregistered", args: args{err: plainErr.Because(errors.New("boom"))}, want: 0},
+		{name: "standard error", args: args{err: errors.New("boom")}, want: 0},
+		{name: "nothing passed", args: args{err: nil}, want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.want, ex.Score(test.args.err))
+		})
+	}
+}